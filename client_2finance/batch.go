@@ -0,0 +1,275 @@
+package client_2finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+)
+
+// defaultBatchConcurrency is how many batch transactions are in flight at
+// once unless WithBatchConcurrency says otherwise.
+const defaultBatchConcurrency = 8
+
+// ErrBatchStopped is the error of the batch items left unsent because an
+// earlier item failed under WithStopOnError.
+var ErrBatchStopped = errors.New("batch stopped after an earlier failure")
+
+// BatchCall makes one contract call on the client it is given, like the call
+// of BuildTransaction.
+type BatchCall func(Client2FinanceNetwork) (types.ContractOutput, error)
+
+// BatchResult is the outcome of one batch item. Transaction is the signed
+// transaction, nil when the item failed before it was signed.
+type BatchResult struct {
+	Index       int
+	Transaction *transaction.Transaction
+	Output      types.ContractOutput
+	Err         error
+}
+
+// BatchProgress reports a batch item whose result is final.
+type BatchProgress struct {
+	Result BatchResult
+
+	// Done counts the items with a final result, this one included, and
+	// Failed those of them that have an error, out of Total.
+	Done   int
+	Failed int
+	Total  int
+}
+
+// BatchOption configures SendBatch.
+type BatchOption func(*batchOptions) error
+
+type batchOptions struct {
+	concurrency int
+	stopOnError bool
+	onProgress  func(BatchProgress)
+}
+
+// WithBatchConcurrency sets how many transactions of a batch are in flight at
+// once.
+func WithBatchConcurrency(concurrency int) BatchOption {
+	return func(o *batchOptions) error {
+		if concurrency < 1 {
+			return validationErrorf("batch concurrency must be at least 1: %d", concurrency)
+		}
+		o.concurrency = concurrency
+		return nil
+	}
+}
+
+// WithStopOnError stops a batch at its first failure: nothing is sent when an
+// item cannot be built or signed, and the items not sent yet when a
+// transaction fails are given ErrBatchStopped. By default every item is
+// tried.
+func WithStopOnError() BatchOption {
+	return func(o *batchOptions) error {
+		o.stopOnError = true
+		return nil
+	}
+}
+
+// WithBatchProgress calls onProgress once for every item of a batch, as its
+// result becomes final. Calls are made one at a time, in completion order.
+func WithBatchProgress(onProgress func(BatchProgress)) BatchOption {
+	return func(o *batchOptions) error {
+		if onProgress == nil {
+			return validationErrorf("batch progress callback is required")
+		}
+		o.onProgress = onProgress
+		return nil
+	}
+}
+
+// SendBatch builds and signs the transactions of calls up front, with the
+// signer and password of ctx like any contract method, then sends them over
+// the client's transport with bounded concurrency, for example to airdrop a
+// token:
+//
+//	calls := make([]client_2finance.BatchCall, len(holders))
+//	for i, holder := range holders {
+//		calls[i] = func(c client_2finance.Client2FinanceNetwork) (types.ContractOutput, error) {
+//			return c.TransferToken(token, holder, "10", nil)
+//		}
+//	}
+//	results, err := c.SendBatch(ctx, calls, client_2finance.WithBatchConcurrency(16))
+//
+// Each call must make exactly one contract call. The results are in the order
+// of calls. The error is the first failure in that order, not counting the
+// items stopped by WithStopOnError, or nil when every item succeeded.
+// Transactions are sent in order but may be committed in any order, so items
+// must not depend on each other; they are retried under the client's retry
+// policy and are not recorded in its outbox.
+func (c *networkClient) SendBatch(ctx context.Context, calls []BatchCall, opts ...BatchOption) ([]BatchResult, error) {
+	if ctx == nil {
+		ctx = c.context()
+	}
+
+	o := batchOptions{concurrency: defaultBatchConcurrency}
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return nil, err
+		}
+	}
+
+	b := &batch{
+		results:    make([]BatchResult, len(calls)),
+		final:      make([]bool, len(calls)),
+		onProgress: o.onProgress,
+	}
+
+	txs, pending := c.signBatch(ctx, calls, b)
+	if o.stopOnError && b.failed > 0 {
+		b.stopped(txs, pending)
+		return b.results, b.firstError()
+	}
+
+	next := make(chan int)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(o.concurrency, len(pending)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				select {
+				case <-stop:
+					b.finish(i, txs[i], types.ContractOutput{}, ErrBatchStopped)
+					continue
+				default:
+				}
+
+				if err := ctx.Err(); err != nil {
+					b.finish(i, txs[i], types.ContractOutput{}, err)
+					continue
+				}
+
+				out, err := c.sendContractTransaction(ctx, txs[i])
+				b.finish(i, txs[i], out, err)
+				if err != nil && o.stopOnError {
+					stopOnce.Do(func() { close(stop) })
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for n, i := range pending {
+		select {
+		case next <- i:
+		case <-stop:
+			b.stopped(txs, pending[n:])
+			break dispatch
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	return b.results, b.firstError()
+}
+
+// signBatch builds and signs the transaction of every call, recording the
+// failures in b. It returns the transactions by call index and the indexes of
+// the signed ones.
+func (c *networkClient) signBatch(ctx context.Context, calls []BatchCall, b *batch) ([]*transaction.Transaction, []int) {
+	signer := c.signerFor(ctx)
+
+	txs := make([]*transaction.Transaction, len(calls))
+	pending := make([]int, 0, len(calls))
+	for i, call := range calls {
+		tx, err := c.signBatchItem(ctx, signer, call)
+		if err != nil {
+			b.finish(i, nil, types.ContractOutput{}, err)
+			continue
+		}
+		txs[i] = tx
+		pending = append(pending, i)
+	}
+
+	return txs, pending
+}
+
+func (c *networkClient) signBatchItem(ctx context.Context, signer wallet_manager.Signer, call BatchCall) (*transaction.Transaction, error) {
+	if call == nil {
+		return nil, validationErrorf("call is required")
+	}
+	if signer == nil {
+		return nil, validationErrorf("wallet manager is required")
+	}
+
+	// Bind the signer to the context of the call as well, so that the
+	// method takes its sender from the signer that signs.
+	tx, err := c.buildTransaction(WithSigner(ctx, signer), call)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := transactionData(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	txSigned, err := c.signTransaction(ctx, signer, tx.ChainID, tx.From, tx.To, tx.Method, data, tx.Version, tx.UUID7)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return txSigned, nil
+}
+
+// batch collects the results of SendBatch and reports progress.
+type batch struct {
+	mu         sync.Mutex
+	results    []BatchResult
+	final      []bool
+	done       int
+	failed     int
+	onProgress func(BatchProgress)
+}
+
+// finish records the final result of item i, once.
+func (b *batch) finish(i int, tx *transaction.Transaction, out types.ContractOutput, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.final[i] {
+		return
+	}
+	b.final[i] = true
+
+	b.results[i] = BatchResult{Index: i, Transaction: tx, Output: out, Err: err}
+	b.done++
+	if err != nil {
+		b.failed++
+	}
+
+	if b.onProgress != nil {
+		b.onProgress(BatchProgress{Result: b.results[i], Done: b.done, Failed: b.failed, Total: len(b.results)})
+	}
+}
+
+// stopped gives ErrBatchStopped to the items at indexes, which were not sent.
+func (b *batch) stopped(txs []*transaction.Transaction, indexes []int) {
+	for _, i := range indexes {
+		b.finish(i, txs[i], types.ContractOutput{}, ErrBatchStopped)
+	}
+}
+
+// firstError returns the error of the first failed item in call order,
+// skipping the items stopped because of it.
+func (b *batch) firstError() error {
+	for _, result := range b.results {
+		if result.Err != nil && !errors.Is(result.Err, ErrBatchStopped) {
+			return fmt.Errorf("batch item %d: %w", result.Index, result.Err)
+		}
+	}
+	return nil
+}
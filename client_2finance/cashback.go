@@ -8,6 +8,8 @@ import (
 
 	"fmt"
 
+	cashbackV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/cashbackV1/domain"
+	cashbackV1Models "gitlab.com/2finance/2finance-network/blockchain/contract/cashbackV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/types"
 	"gitlab.com/2finance/2finance-network/blockchain/utils"
@@ -25,36 +27,36 @@ func (c *networkClient) AddCashback(
 	paused bool,
 ) (types.ContractOutput, error) {
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if owner == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner not set")
+		return types.ContractOutput{}, validationErrorf("owner not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(owner); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 	if programType != "fixed-percentage" && programType != "variable-percentage" {
-		return types.ContractOutput{}, fmt.Errorf("invalid program_type: %s", programType)
+		return types.ContractOutput{}, validationErrorf("invalid program_type: %s", programType)
 	}
 	if percentage == "" {
-		return types.ContractOutput{}, fmt.Errorf("percentage not set")
+		return types.ContractOutput{}, validationErrorf("percentage not set")
 	}
 
 	to := address
@@ -75,7 +77,7 @@ func (c *networkClient) AddCashback(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	cashback, err := c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	cashback, err := c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to add cashback: %w", err)
 	}
@@ -93,27 +95,27 @@ func (c *networkClient) UpdateCashback(
 ) (types.ContractOutput, error) {
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if tokenAddress != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 		}
 	}
 	if programType != "fixed-percentage" && programType != "variable-percentage" {
-		return types.ContractOutput{}, fmt.Errorf("invalid program_type: %s", programType)
+		return types.ContractOutput{}, validationErrorf("invalid program_type: %s", programType)
 	}
 	if percentage == "" {
-		return types.ContractOutput{}, fmt.Errorf("percentage not set")
+		return types.ContractOutput{}, validationErrorf("percentage not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -133,25 +135,25 @@ func (c *networkClient) UpdateCashback(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // PauseCashBack pauses a cashback program. OnlyOwner.
 func (c *networkClient) PauseCashback(address string, pause bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if !pause {
-		return types.ContractOutput{}, fmt.Errorf("pause must be true: Pause: %t", pause)
+		return types.ContractOutput{}, validationErrorf("pause must be true: Pause: %t", pause)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -167,25 +169,25 @@ func (c *networkClient) PauseCashback(address string, pause bool) (types.Contrac
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // UnpauseCashback unpauses a cashback program. OnlyOwner.
 func (c *networkClient) UnpauseCashback(address string, pause bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if pause {
-		return types.ContractOutput{}, fmt.Errorf("pause must be false: Pause: %t", pause)
+		return types.ContractOutput{}, validationErrorf("pause must be false: Pause: %t", pause)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -201,39 +203,39 @@ func (c *networkClient) UnpauseCashback(address string, pause bool) (types.Contr
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // DepositCashBack funds the cashback pool (token inferred from state).
 func (c *networkClient) DepositCashbackFunds(address, tokenAddress, amount, tokenType, uuid string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 	if tokenType == "" {
-		return types.ContractOutput{}, fmt.Errorf("token type not set")
+		return types.ContractOutput{}, validationErrorf("token type not set")
 	}
 	if tokenType == domain.NON_FUNGIBLE {
 		if uuid == "" {
-			return types.ContractOutput{}, fmt.Errorf("uuid must be set for non-fungible tokens")
+			return types.ContractOutput{}, validationErrorf("uuid must be set for non-fungible tokens")
 		}
 	}
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -252,7 +254,7 @@ func (c *networkClient) DepositCashbackFunds(address, tokenAddress, amount, toke
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	contractOutput, err := c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	contractOutput, err := c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to deposit cashback: %w", err)
 	}
@@ -262,34 +264,34 @@ func (c *networkClient) DepositCashbackFunds(address, tokenAddress, amount, toke
 // WithdrawCashback withdraws funds from the cashback pool. OnlyOwner.
 func (c *networkClient) WithdrawCashbackFunds(address, tokenAddress, amount, tokenType, uuid string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 	if tokenType == "" {
-		return types.ContractOutput{}, fmt.Errorf("token type not set")
+		return types.ContractOutput{}, validationErrorf("token type not set")
 	}
 	if tokenType == domain.NON_FUNGIBLE {
 		if uuid == "" {
-			return types.ContractOutput{}, fmt.Errorf("uuid must be set for non-fungible tokens")
+			return types.ContractOutput{}, validationErrorf("uuid must be set for non-fungible tokens")
 		}
 	}
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -307,21 +309,21 @@ func (c *networkClient) WithdrawCashbackFunds(address, tokenAddress, amount, tok
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // GetCashBack reads a single cashback state.
 func (c *networkClient) GetCashback(address string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("cashback address must be set")
+		return types.ContractOutput{}, validationErrorf("cashback address must be set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid cashback address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid cashback address: %w", err)
 	}
 
 	method := cashbackV1.METHOD_GET_CASHBACK
@@ -339,30 +341,30 @@ func (c *networkClient) ListCashbacks(
 	limit int,
 	ascending bool,
 ) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if owner != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(owner); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 		}
 	}
 	if tokenAddress != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 		}
 	}
 	if programType != "" && programType != "fixed-percentage" && programType != "variable-percentage" {
-		return types.ContractOutput{}, fmt.Errorf("invalid program_type: %s", programType)
+		return types.ContractOutput{}, validationErrorf("invalid program_type: %s", programType)
 	}
 	if page < 1 {
-		return types.ContractOutput{}, fmt.Errorf("page must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("page must be greater than 0")
 	}
 	if limit < 1 {
-		return types.ContractOutput{}, fmt.Errorf("limit must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("limit must be greater than 0")
 	}
 
 	method := cashbackV1.METHOD_LIST_CASHBACKS
@@ -382,27 +384,27 @@ func (c *networkClient) ListCashbacks(
 
 func (c *networkClient) ClaimCashback(address, amount, tokenType, uuid string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 	if tokenType == "" {
-		return types.ContractOutput{}, fmt.Errorf("token type not set")
+		return types.ContractOutput{}, validationErrorf("token type not set")
 	}
 	if tokenType == domain.NON_FUNGIBLE {
 		if uuid == "" {
-			return types.ContractOutput{}, fmt.Errorf("uuid must be set for non-fungible tokens")
+			return types.ContractOutput{}, validationErrorf("uuid must be set for non-fungible tokens")
 		}
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -420,5 +422,21 @@ func (c *networkClient) ClaimCashback(address, amount, tokenType, uuid string) (
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
+}
+
+// DecodeCashback returns the cashback program logged by AddCashback and the
+// other cashback write methods.
+func DecodeCashback(out types.ContractOutput, err error) (cashbackV1Domain.Cashback, error) {
+	return decodeEventResult[cashbackV1Domain.Cashback](out, err, "")
+}
+
+// DecodeCashbackState returns the cashback program read by GetCashback.
+func DecodeCashbackState(out types.ContractOutput, err error) (cashbackV1Models.CashbackStateModel, error) {
+	return decodeStateResult[cashbackV1Models.CashbackStateModel](out, err)
+}
+
+// DecodeCashbacks returns the cashback programs read by ListCashbacks.
+func DecodeCashbacks(out types.ContractOutput, err error) ([]cashbackV1Models.CashbackStateModel, error) {
+	return decodeStateResult[[]cashbackV1Models.CashbackStateModel](out, err)
 }
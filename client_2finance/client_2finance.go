@@ -1,13 +1,15 @@
 package client_2finance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"sync"
 	"time"
 
 	"gitlab.com/2finance/2finance-network/blockchain/block"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/contractV1"
+	contractV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/contractV1/domain"
 	inputsDropV1 "gitlab.com/2finance/2finance-network/blockchain/contract/dropV1/inputs"
 	inputsPaymentV1 "gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1/inputs"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
@@ -18,8 +20,6 @@ import (
 	"gitlab.com/2finance/2finance-network/blockchain/virtualmachine"
 	"gitlab.com/2finance/2finance-network/infra/mqtt"
 
-	"strings"
-
 	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
 	"github.com/google/uuid"
 	"gitlab.com/2finance/2finance-network/infra/event"
@@ -28,10 +28,22 @@ import (
 // Interface exposes the client behavior
 type Client2FinanceNetwork interface {
 	// Client
-	SetChainID(chainId uint8)
-	SetWalletManager(wallet wallet_manager.IWalletManager)
+	SetChainID(chainId uint8) error
+	SetWalletManager(wallet wallet_manager.IWalletManager) error
+	// SetSigner makes signer sign the calls made through the client, like
+	// SetWalletManager does for a local wallet. The setters are safe to call
+	// concurrently with other calls; a change applies to the calls that
+	// start after it, through the client and every copy made by WithContext.
+	SetSigner(signer wallet_manager.Signer) error
+
+	// WithContext returns a client bound to ctx. Every call made through the
+	// returned client, contract methods included, gives up as soon as ctx is
+	// done. The returned client shares the connection and the settings of
+	// the setters with the original one.
+	WithContext(ctx context.Context) Client2FinanceNetwork
 
 	SendTransaction(method string, tx interface{}, replyTo string) (outputBytes []byte, err error)
+	SendTransactionContext(ctx context.Context, method string, tx interface{}, replyTo string) (outputBytes []byte, err error)
 
 	// CHAIN
 	ListTransactions(from, to, hash string, dataFilter map[string]interface{}, version uint8,
@@ -56,13 +68,36 @@ type Client2FinanceNetwork interface {
 		version uint8,
 		uuid7 string,
 	) (types.ContractOutput, error)
+	SignAndSendTransactionContext(
+		ctx context.Context,
+		chainId uint8,
+		from string,
+		to string,
+		method string,
+		data map[string]interface{},
+		version uint8,
+		uuid7 string,
+	) (types.ContractOutput, error)
 	GetState(
 		to string,
 		method string,
 		data map[string]interface{}) (types.ContractOutput, error)
+	GetStateContext(
+		ctx context.Context,
+		to string,
+		method string,
+		data map[string]interface{}) (types.ContractOutput, error)
 	ListBlocks(blockNumber uint64, blockTimestamp time.Time, hash string, previousHash string, transactionMerkleRoot string,
 		page, limit int,
 		ascending bool) ([]block.Block, error)
+	WaitForTransaction(ctx context.Context, hash string, opts ...WaitOption) (Confirmation, error)
+	SubscribeLogs(ctx context.Context, filter blockchainLog.LogParams, opts ...SubscribeOption) (<-chan blockchainLog.Log, error)
+	SubscribeBlocks(ctx context.Context, opts ...SubscribeOption) (<-chan block.Block, error)
+	SendMultisigTransaction(ctx context.Context, m *wallet_manager.MultisigTransaction) (types.ContractOutput, error)
+	BuildTransaction(from string, call func(Client2FinanceNetwork) (types.ContractOutput, error)) (string, error)
+	BroadcastSignedTransaction(ctx context.Context, signed string) (types.ContractOutput, error)
+	ResubmitOutbox(ctx context.Context) ([]OutboxEntry, error)
+	SendBatch(ctx context.Context, calls []BatchCall, opts ...BatchOption) ([]BatchResult, error)
 
 	// WALLET
 	AddWallet(address, pubKey string) (types.ContractOutput, error)
@@ -398,75 +433,157 @@ type Client2FinanceNetwork interface {
 	ListPrizes(raffleAddress string, page, limit int, asc bool) (types.ContractOutput, error)
 }
 
+const defaultResponseTimeout = 10 * time.Second
+
+// networkClient is safe for concurrent use. Copies made by WithContext share
+// the transport and the settings.
 type networkClient struct {
-	mqttClient mqtt.IMQTT
-	replyTo    string
-	chainId    uint8
-	walletManager wallet_manager.IWalletManager
+	ctx       context.Context
+	transport Transport
+	replyTo   string
+	settings  *settings
+
+	responseTimeout time.Duration
+	retryPolicy     RetryPolicy
+	outbox          *Outbox
+}
+
+// New connects to broker and returns a client configured by opts. Options are
+// validated before connecting and the connection error, if any, is returned.
+func New(broker string, opts ...Option) (Client2FinanceNetwork, error) {
+	if err := validateBrokerURL(broker); err != nil {
+		return nil, err
+	}
+
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	mqttClient := mqtt.New(broker, o.clientID, o.debug)
+	if err := mqttClient.Connect(); err != nil {
+		return nil, fmt.Errorf("failed to connect to broker %s: %w", broker, err)
+	}
+
+	return newNetworkClient(NewMQTTTransport(mqttClient), o), nil
 }
 
-// New creates a new client
-func New(broker, clientID string, debug bool, walletManager wallet_manager.IWalletManager) Client2FinanceNetwork {
+// NewWithTransport returns a client that talks to the node through transport
+// instead of MQTT. WithClientID and WithDebug do not apply.
+func NewWithTransport(transport Transport, opts ...Option) (Client2FinanceNetwork, error) {
+	if transport == nil {
+		return nil, validationErrorf("transport cannot be nil")
+	}
 
-	mqttClient := mqtt.New(broker, clientID, debug)
-	mqttClient.Connect()
-	replyTo := uuid.NewString()
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newNetworkClient(transport, o), nil
+}
+
+func newNetworkClient(transport Transport, o options) *networkClient {
 	return &networkClient{
-		mqttClient: mqttClient,
-		replyTo:    replyTo,
-		walletManager: walletManager,
+		ctx:             context.Background(),
+		transport:       transport,
+		replyTo:         uuid.NewString(),
+		responseTimeout: o.responseTimeout,
+		retryPolicy:     o.retryPolicy,
+		outbox:          o.outbox,
+		settings:        &settings{chainId: o.chainId, signer: o.signer},
 	}
 }
 
-func (c *networkClient) SetChainID(chainId uint8) {
-	if chainId < 1 || chainId > 2 {
-		log.Fatalf("invalid chainId: %d, available values are 2 testnet or 1 mainnet", chainId)
+// settings holds what SetChainID, SetWalletManager and SetSigner change, in
+// one place shared by the client and its copies.
+type settings struct {
+	mu      sync.RWMutex
+	chainId uint8
+	signer  wallet_manager.Signer
+}
+
+// WithContext returns a shallow copy of the client bound to ctx.
+func (c *networkClient) WithContext(ctx context.Context) Client2FinanceNetwork {
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	c.chainId = chainId
+
+	return c.withContext(ctx)
+}
+
+// withContext is WithContext without the conversion to the interface.
+func (c *networkClient) withContext(ctx context.Context) *networkClient {
+	cp := *c
+	cp.ctx = ctx
+	return &cp
 }
 
-func (c *networkClient) SetWalletManager(wallet wallet_manager.IWalletManager) {
-	if wallet == nil {
-		log.Fatalf("wallet manager cannot be nil")
+// context returns the context the client is bound to.
+func (c *networkClient) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
 	}
-	c.walletManager = wallet
+	return c.ctx
 }
 
-func (c *networkClient) GetChainID() uint8 {
-	return c.chainId
+func (c *networkClient) SetChainID(chainId uint8) error {
+	if err := validateChainID(chainId); err != nil {
+		return err
+	}
+	c.settings.mu.Lock()
+	defer c.settings.mu.Unlock()
+
+	c.settings.chainId = chainId
+	return nil
 }
 
-// SendRequest publishes the payload to the MQTT broker
-func (c *networkClient) sendRequest(topic string, payload interface{}) error {
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("marshal payload error: %w", err)
+func (c *networkClient) SetWalletManager(wallet wallet_manager.IWalletManager) error {
+	if err := validateWalletManager(wallet); err != nil {
+		return err
 	}
+	c.settings.mu.Lock()
+	defer c.settings.mu.Unlock()
+
+	c.settings.signer = wallet
+	return nil
+}
 
-	if err := c.mqttClient.Publish(topic, data); err != nil {
-		return fmt.Errorf("publish error: %w", err)
+func (c *networkClient) SetSigner(signer wallet_manager.Signer) error {
+	if err := validateSigner(signer); err != nil {
+		return err
 	}
+	c.settings.mu.Lock()
+	defer c.settings.mu.Unlock()
 
+	c.settings.signer = signer
 	return nil
 }
 
+func (c *networkClient) GetChainID() uint8 {
+	c.settings.mu.RLock()
+	defer c.settings.mu.RUnlock()
+
+	return c.settings.chainId
+}
+
 func (c *networkClient) ListTransactions(from, to, hash string, dataFilter map[string]interface{}, version uint8,
 	page, limit int,
 	ascending bool) ([]transaction.Transaction, error) {
 
 	if from == "" && to == "" && hash == "" {
-		return nil, fmt.Errorf("at least one of from, to or hash must be set")
+		return nil, validationErrorf("at least one of from, to or hash must be set")
 	}
 
 	if from != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-			return nil, fmt.Errorf("invalid from address: %w", err)
+			return nil, validationErrorf("invalid from address: %w", err)
 		}
 	}
 
 	if to != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(to); err != nil {
-			return nil, fmt.Errorf("invalid to address: %w", err)
+			return nil, validationErrorf("invalid to address: %w", err)
 		}
 	}
 
@@ -502,7 +619,7 @@ func (c *networkClient) ListLogs(logType []string, logIndex uint, transactionHas
 	page, limit int,
 	ascending bool) ([]blockchainLog.Log, error) {
 	if len(logType) == 0 && transactionHash == "" && contractAddress == "" {
-		return nil, fmt.Errorf("at least one of logType, transactionHash or contractAddress must be set")
+		return nil, validationErrorf("at least one of logType, transactionHash or contractAddress must be set")
 	}
 
 	logInput := blockchainLog.LogParams{
@@ -529,56 +646,41 @@ func (c *networkClient) ListLogs(logType []string, logIndex uint, transactionHas
 	return logs, nil
 }
 
-func (c *networkClient) sendAndWaitResponse(method string, params interface{}, replyTo string) ([]byte, error) {
-	replyTopic := fmt.Sprintf("%s/%s", event.TRANSACTIONS_RESPONSE_TOPIC, replyTo)
-	responseChan := make(chan []byte, 1)
-	if err := c.receiveResponse(replyTopic, func(data []byte) {
-		responseChan <- data
-	}); err != nil {
-		return nil, fmt.Errorf("failed to subscribe to reply topic: %w", err)
+func (c *networkClient) sendAndWaitResponse(ctx context.Context, method string, params interface{}, replyTo string) (event.ResponsePayload, error) {
+	if err := ctx.Err(); err != nil {
+		return event.ResponsePayload{}, fmt.Errorf("request aborted before sending: %w", contextError(err))
 	}
 
-	payload := event.RequestPayload{
+	ctx, cancel := context.WithTimeout(ctx, c.responseTimeout)
+	defer cancel()
+
+	resp, err := c.transport.RoundTrip(ctx, replyTo, event.RequestPayload{
 		Method: method,
 		Params: params,
-	}
-	// Use the original topic and append the replyTo
-	orig := event.TRANSACTIONS_REQUEST_TOPIC
-	base := strings.TrimSuffix(orig, "/+")
-	newTopic := fmt.Sprintf("%s/%s", base, replyTo)
-	if err := c.sendRequest(newTopic, payload); err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+	})
+	if err != nil {
+		return event.ResponsePayload{}, contextError(err)
 	}
 
-	select {
-	case resp := <-responseChan:
-		return resp, nil
-	case <-time.After(10 * time.Second):
-		return nil, fmt.Errorf("timeout waiting for response on topic %s", replyTo)
-	}
+	return resp, nil
 }
 
-// ReceiveResponse subscribes to a topic and calls the handler with raw payload
-func (c *networkClient) receiveResponse(topic string, handler func([]byte)) error {
-	return c.mqttClient.SubscribeWithHandler(topic, func(_ mqtt.Client, msg mqtt.Message) {
-		handler(msg.Payload())
-	})
+func (c *networkClient) SendTransaction(method string, tx interface{}, replyTo string) (outputBytes []byte, err error) {
+	return c.SendTransactionContext(c.context(), method, tx, replyTo)
 }
 
-func (c *networkClient) SendTransaction(method string, tx interface{}, replyTo string) (outputBytes []byte, err error) {
+// SendTransactionContext is SendTransaction honoring ctx's deadline and cancellation.
+func (c *networkClient) SendTransactionContext(ctx context.Context, method string, tx interface{}, replyTo string) (outputBytes []byte, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	// Send the transaction to the network
-	bytes, err := c.sendAndWaitResponse(method, tx, replyTo)
+	resp, err := c.sendAndWaitResponse(ctx, method, tx, replyTo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send transaction: - Handler Request %w", err)
 	}
 
-	// Decode the response envelope
-	var resp event.ResponsePayload
-	if err := json.Unmarshal(bytes, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
 	// Re-encode the inner Data to raw JSON bytes
 	outputBytes, err = json.Marshal(resp.Data)
 	if err != nil {
@@ -586,13 +688,13 @@ func (c *networkClient) SendTransaction(method string, tx interface{}, replyTo s
 	}
 
 	if resp.Status == event.RESPONSE_STATUS_ERROR {
-		return nil, fmt.Errorf("error in response: %s", resp.Message)
+		return nil, &ContractError{Method: method, Message: resp.Message}
 	}
 
 	return outputBytes, nil
 }
 
-// SendTransaction builds, signs, and sends a transaction to the blockchain.
+// SignAndSendTransaction builds, signs, and sends a transaction to the blockchain.
 func (c *networkClient) SignAndSendTransaction(
 	chainId uint8,
 	from string,
@@ -601,40 +703,52 @@ func (c *networkClient) SignAndSendTransaction(
 	data map[string]interface{},
 	version uint8,
 	uuid7 string,
+) (types.ContractOutput, error) {
+	return c.SignAndSendTransactionContext(c.context(), chainId, from, to, method, data, version, uuid7)
+}
+
+// SignAndSendTransactionContext is SignAndSendTransaction honoring ctx's deadline and cancellation.
+func (c *networkClient) SignAndSendTransactionContext(
+	ctx context.Context,
+	chainId uint8,
+	from string,
+	to string,
+	method string,
+	data map[string]interface{},
+	version uint8,
+	uuid7 string,
 ) (types.ContractOutput, error) {
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
-	if c.walletManager == nil {
-		return types.ContractOutput{}, fmt.Errorf("wallet manager is required")
+	if builder, ok := c.builderFor(ctx); ok {
+		return types.ContractOutput{}, builder.add(chainId, from, to, method, data, version, uuid7)
 	}
 
-	txSigned, err := c.walletManager.SignTransaction(
-		chainId,
-		from,
-		to,
-		method,
-		data,
-		version,
-		uuid7,
-	)
-	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("failed to sign transaction: %w", err)
+	signer := c.signerFor(ctx)
+	if signer == nil {
+		return types.ContractOutput{}, validationErrorf("wallet manager is required")
 	}
 
-	contractOutputBytes, err := c.SendTransaction(
-		virtualmachine.REQUEST_METHOD_SEND,
-		txSigned,
-		c.replyTo,
-	)
+	if c.outbox != nil {
+		return c.sendThroughOutbox(ctx, signer, chainId, from, to, method, data, version, uuid7)
+	}
+
+	txSigned, err := c.signTransaction(ctx, signer, chainId, from, to, method, data, version, uuid7)
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("failed to send transaction: %w", err)
+		return types.ContractOutput{}, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	var contractOutput types.ContractOutput
-	if err := json.Unmarshal(contractOutputBytes, &contractOutput); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("failed to unmarshal contract output: %w", err)
+	return c.sendContractTransaction(ctx, txSigned)
+}
+
+// sendContractTransaction sends a signed transaction and decodes the
+// contract output.
+func (c *networkClient) sendContractTransaction(ctx context.Context, txSigned *transaction.Transaction) (types.ContractOutput, error) {
+	contractOutput, err := c.sendSignedTransaction(ctx, txSigned)
+	if err != nil {
+		return types.ContractOutput{}, fmt.Errorf("failed to send transaction: %w", withContractMethod(err, txSigned.Method))
 	}
 
 	return contractOutput, nil
@@ -644,6 +758,16 @@ func (c *networkClient) GetState(
 	to string,
 	method string,
 	data map[string]interface{},
+) (types.ContractOutput, error) {
+	return c.GetStateContext(c.context(), to, method, data)
+}
+
+// GetStateContext is GetState honoring ctx's deadline and cancellation.
+func (c *networkClient) GetStateContext(
+	ctx context.Context,
+	to string,
+	method string,
+	data map[string]interface{},
 ) (types.ContractOutput, error) {
 	// Convert data map to JSONB
 	jsonData, err := utils.MapToRawMessage(data)
@@ -659,9 +783,9 @@ func (c *networkClient) GetState(
 	}
 
 	// Use a unique reply topic
-	contractOutputBytes, err := c.SendTransaction(virtualmachine.REQUEST_METHOD_GET_STATE, txInput, c.replyTo)
+	contractOutputBytes, err := c.SendTransactionContext(ctx, virtualmachine.REQUEST_METHOD_GET_STATE, txInput, c.replyTo)
 	if err != nil {
-		return types.ContractOutput{}, err
+		return types.ContractOutput{}, withContractMethod(err, method)
 	}
 
 	var contractOutput types.ContractOutput
@@ -701,21 +825,22 @@ func (c *networkClient) ListBlocks(blockNumber uint64, blockTimestamp time.Time,
 }
 
 func (c *networkClient) DeployContract1(contractVersion string) (types.ContractOutput, error) {
-	if c.walletManager == nil {
-		return types.ContractOutput{}, fmt.Errorf("wallet manager is required")
+	signer := c.signer()
+	if signer == nil {
+		return types.ContractOutput{}, validationErrorf("wallet manager is required")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := signer.GetPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address is required")
+		return types.ContractOutput{}, validationErrorf("from address is required")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if contractVersion == "" {
-		return types.ContractOutput{}, fmt.Errorf("contract version is required")
+		return types.ContractOutput{}, validationErrorf("contract version is required")
 	}
 
 	to := types.DEPLOY_CONTRACT_ADDRESS
@@ -733,7 +858,7 @@ func (c *networkClient) DeployContract1(contractVersion string) (types.ContractO
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -749,29 +874,30 @@ func (c *networkClient) DeployContract1(contractVersion string) (types.ContractO
 }
 
 func (c *networkClient) DeployContract2(contractVersion, contractAddress string) (types.ContractOutput, error) {
-	if c.walletManager == nil {
-		return types.ContractOutput{}, fmt.Errorf("wallet manager is required")
+	signer := c.signer()
+	if signer == nil {
+		return types.ContractOutput{}, validationErrorf("wallet manager is required")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := signer.GetPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address is required")
+		return types.ContractOutput{}, validationErrorf("from address is required")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if contractVersion == "" {
-		return types.ContractOutput{}, fmt.Errorf("contract version is required")
+		return types.ContractOutput{}, validationErrorf("contract version is required")
 	}
 
 	if contractAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("contract address is required")
+		return types.ContractOutput{}, validationErrorf("contract address is required")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(contractAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid contract address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid contract address: %w", err)
 	}
 
 	to := contractAddress
@@ -789,7 +915,7 @@ func (c *networkClient) DeployContract2(contractVersion, contractAddress string)
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -803,3 +929,9 @@ func (c *networkClient) DeployContract2(contractVersion, contractAddress string)
 
 	return contractOutput, nil
 }
+
+// DecodeDeployedContract returns the contract created by DeployContract1 or
+// DeployContract2.
+func DecodeDeployedContract(out types.ContractOutput, err error) (contractV1Domain.Contract, error) {
+	return decodeEventResult[contractV1Domain.Contract](out, err, contractV1Domain.DEPLOYED_CONTRACT_LOG)
+}
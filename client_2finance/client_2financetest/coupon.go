@@ -0,0 +1,381 @@
+package client_2financetest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/contract/couponV1"
+	couponV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/couponV1/domain"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
+	tokenV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
+)
+
+// couponRecord is a coupon program. Its vouchers are units of a
+// non-fungible token owned by the coupon address.
+type couponRecord struct {
+	address          string
+	owner            string
+	tokenAddress     string
+	discountType     string
+	percentageBPS    string
+	fixedAmount      string
+	minOrder         string
+	startAt          time.Time
+	expiredAt        time.Time
+	paused           bool
+	stackable        bool
+	maxRedemptions   int
+	perUserLimit     int
+	passcodeHash     string
+	totalRedemptions int
+	redemptions      map[string]int
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+type couponInput struct {
+	TokenAddress   string    `json:"token_address"`
+	DiscountType   string    `json:"discount_type"`
+	PercentageBPS  string    `json:"percentage_bps"`
+	FixedAmount    string    `json:"fixed_amount"`
+	MinOrder       string    `json:"min_order"`
+	StartAt        time.Time `json:"start_at"`
+	ExpiredAt      time.Time `json:"expired_at"`
+	Paused         bool      `json:"paused"`
+	Stackable      bool      `json:"stackable"`
+	MaxRedemptions int       `json:"max_redemptions"`
+	PerUserLimit   int       `json:"per_user_limit"`
+	PasscodeHash   string    `json:"passcode_hash"`
+	VoucherOwner   string    `json:"voucher_owner"`
+	Amount         string    `json:"amount"`
+	AssetGLBUri    string    `json:"asset_glb_uri"`
+}
+
+func (cp *couponRecord) object() object {
+	return object{
+		"address":           cp.address,
+		"owner":             cp.owner,
+		"token_address":     cp.tokenAddress,
+		"discount_type":     cp.discountType,
+		"percentage_bps":    cp.percentageBPS,
+		"fixed_amount":      cp.fixedAmount,
+		"min_order":         cp.minOrder,
+		"start_at":          cp.startAt,
+		"expired_at":        cp.expiredAt,
+		"paused":            cp.paused,
+		"stackable":         cp.stackable,
+		"max_redemptions":   cp.maxRedemptions,
+		"per_user_limit":    cp.perUserLimit,
+		"passcode_hash":     cp.passcodeHash,
+		"total_redemptions": cp.totalRedemptions,
+		"created_at":        cp.createdAt,
+		"updated_at":        cp.updatedAt,
+	}
+}
+
+// applyTerms sets the discount terms of in, validating them first.
+func (cp *couponRecord) applyTerms(in couponInput) error {
+	discountType := in.DiscountType
+	if discountType == "" {
+		discountType = cp.discountType
+	}
+	switch discountType {
+	case couponV1Domain.DISCOUNT_TYPE_PERCENTAGE:
+		bps, err := parseAmount(in.PercentageBPS)
+		if err != nil || bps.Sign() <= 0 || bps.Cmp(big.NewInt(10000)) > 0 {
+			return fmt.Errorf("invalid percentage_bps: %q", in.PercentageBPS)
+		}
+	case couponV1Domain.DISCOUNT_TYPE_FIXED:
+		amount, err := parseAmount(in.FixedAmount)
+		if err != nil || amount.Sign() <= 0 {
+			return fmt.Errorf("invalid fixed_amount: %q", in.FixedAmount)
+		}
+	default:
+		return fmt.Errorf("invalid discount_type: %s", discountType)
+	}
+	if in.MinOrder != "" {
+		if _, err := parseAmount(in.MinOrder); err != nil {
+			return fmt.Errorf("invalid min_order: %w", err)
+		}
+	}
+	if !in.StartAt.IsZero() && !in.ExpiredAt.IsZero() && !in.ExpiredAt.After(in.StartAt) {
+		return fmt.Errorf("expired_at must be after start_at")
+	}
+
+	cp.discountType = discountType
+	cp.percentageBPS = in.PercentageBPS
+	cp.fixedAmount = in.FixedAmount
+	cp.minOrder = in.MinOrder
+	cp.startAt = in.StartAt
+	cp.expiredAt = in.ExpiredAt
+	cp.stackable = in.Stackable
+	cp.maxRedemptions = in.MaxRedemptions
+	cp.perUserLimit = in.PerUserLimit
+	if in.PasscodeHash != "" {
+		cp.passcodeHash = in.PasscodeHash
+	}
+	return nil
+}
+
+// discount returns the discount the coupon gives on orderAmount.
+func (cp *couponRecord) discount(orderAmount *big.Int) *big.Int {
+	if cp.discountType == couponV1Domain.DISCOUNT_TYPE_PERCENTAGE {
+		bps, _ := parseAmount(cp.percentageBPS)
+		d := new(big.Int).Mul(orderAmount, bps)
+		return d.Quo(d, big.NewInt(10000))
+	}
+
+	fixed, _ := parseAmount(cp.fixedAmount)
+	if fixed.Cmp(orderAmount) > 0 {
+		return new(big.Int).Set(orderAmount)
+	}
+	return fixed
+}
+
+func (n *Node) sendCoupon(c call, out *output) error {
+	if c.method == couponV1.METHOD_ADD_COUPON {
+		return n.addCoupon(c, out)
+	}
+
+	cp, ok := n.coupons[c.to]
+	if !ok {
+		return fmt.Errorf("coupon not found: %s", c.to)
+	}
+
+	switch c.method {
+	case couponV1.METHOD_UPDATE_COUPON:
+		var in couponInput
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		if c.from != cp.owner {
+			return fmt.Errorf("only the coupon owner can update it: %s", c.from)
+		}
+		if in.TokenAddress != "" && in.TokenAddress != cp.tokenAddress {
+			return fmt.Errorf("coupon token address cannot change: %s", in.TokenAddress)
+		}
+		updated := *cp
+		if err := updated.applyTerms(in); err != nil {
+			return err
+		}
+		updated.updatedAt = c.now
+		*cp = updated
+		out.log(cp.address, couponV1Domain.COUPON_UPDATED_LOG, cp.object())
+
+	case couponV1.METHOD_PAUSE_COUPON, couponV1.METHOD_UNPAUSE_COUPON:
+		if c.from != cp.owner {
+			return fmt.Errorf("only the coupon owner can pause it: %s", c.from)
+		}
+		cp.paused = c.method == couponV1.METHOD_PAUSE_COUPON
+		cp.updatedAt = c.now
+		logType := couponV1Domain.COUPON_PAUSED_LOG
+		if !cp.paused {
+			logType = couponV1Domain.COUPON_UNPAUSED_LOG
+		}
+		out.log(cp.address, logType, object{
+			"address": cp.address,
+			"paused":  cp.paused,
+		})
+
+	case couponV1.METHOD_ISSUE_VOUCHER:
+		var in struct {
+			ToAddress string `json:"to_address"`
+			Amount    string `json:"amount"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		if c.from != cp.owner {
+			return fmt.Errorf("only the coupon owner can issue vouchers: %s", c.from)
+		}
+		if cp.paused {
+			return fmt.Errorf("coupon %s is paused", cp.address)
+		}
+		t := n.tokens[cp.tokenAddress]
+		if err := t.mint(in.ToAddress, in.Amount, c.now, out.delegate()); err != nil {
+			return err
+		}
+		out.log(cp.address, couponV1Domain.VOUCHER_ISSUED_LOG, object{
+			"address":    cp.address,
+			"to_address": in.ToAddress,
+			"amount":     in.Amount,
+		})
+
+	case couponV1.METHOD_REDEEM_VOUCHER:
+		var in struct {
+			OrderAmount string `json:"order_amount"`
+			Passcode    string `json:"passcode"`
+			VoucherUUID string `json:"voucher_uuid"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		orderAmount, err := parseAmount(in.OrderAmount)
+		if err != nil {
+			return err
+		}
+		if err := cp.checkRedeemable(c.from, in.Passcode, orderAmount, c.now); err != nil {
+			return err
+		}
+		t := n.tokens[cp.tokenAddress]
+		if err := t.burn(c.from, "", []string{in.VoucherUUID}, c.now, out.delegate()); err != nil {
+			return err
+		}
+		cp.totalRedemptions++
+		cp.redemptions[c.from]++
+		cp.updatedAt = c.now
+		out.log(cp.address, couponV1Domain.VOUCHER_REDEEMED_LOG, object{
+			"coupon_address":  cp.address,
+			"token_address":   cp.tokenAddress,
+			"user_address":    c.from,
+			"order_amount":    in.OrderAmount,
+			"discount_amount": cp.discount(orderAmount).String(),
+			"voucher_uuid":    in.VoucherUUID,
+		})
+
+	default:
+		return fmt.Errorf("coupon method not supported by the fake node: %s", c.method)
+	}
+
+	return nil
+}
+
+// addCoupon stores the coupon at the contract address, creates its voucher
+// token and hands the initial vouchers to the voucher owner.
+func (n *Node) addCoupon(c call, out *output) error {
+	var in couponInput
+	if err := c.decode(&in); err != nil {
+		return err
+	}
+	metadata := object{}
+	if err := c.decode(&metadata); err != nil {
+		return err
+	}
+	if _, exists := n.coupons[c.to]; exists {
+		return fmt.Errorf("coupon already exists: %s", c.to)
+	}
+	if in.VoucherOwner == "" {
+		return fmt.Errorf("voucher owner not set")
+	}
+
+	cp := &couponRecord{
+		address:     c.to,
+		owner:       c.from,
+		paused:      in.Paused,
+		redemptions: make(map[string]int),
+		createdAt:   c.now,
+		updatedAt:   c.now,
+	}
+	if err := cp.applyTerms(in); err != nil {
+		return err
+	}
+
+	tokenAddress, err := newAddress()
+	if err != nil {
+		return err
+	}
+	metadata["asset_type"] = tokenV1Domain.COUPON_ASSET_TYPE
+	t, err := n.createToken(tokenAddress, addTokenInput{
+		Owner:        cp.address,
+		TotalSupply:  in.Amount,
+		TokenType:    tokenV1Domain.NON_FUNGIBLE,
+		AssetGLBUri:  in.AssetGLBUri,
+		Transferable: true,
+	}, metadata, c.now, out.delegate())
+	if err != nil {
+		return err
+	}
+	n.contracts[tokenAddress] = tokenV1.TOKEN_CONTRACT_V1
+	cp.tokenAddress = tokenAddress
+
+	if units := t.units(cp.address); len(units) > 0 && in.VoucherOwner != cp.address {
+		uuids := make([]string, 0, len(units))
+		for _, u := range units {
+			uuids = append(uuids, u.uuid)
+		}
+		if err := t.transfer(cp.address, in.VoucherOwner, "", uuids, c.now, out.delegate()); err != nil {
+			return err
+		}
+	}
+
+	n.coupons[cp.address] = cp
+	n.couponOrder = append(n.couponOrder, cp.address)
+	out.log(cp.address, couponV1Domain.COUPON_CREATED_LOG, cp.object())
+
+	return nil
+}
+
+func (cp *couponRecord) checkRedeemable(user, passcode string, orderAmount *big.Int, now time.Time) error {
+	if cp.paused {
+		return fmt.Errorf("coupon %s is paused", cp.address)
+	}
+	if !cp.startAt.IsZero() && now.Before(cp.startAt) {
+		return fmt.Errorf("coupon %s is not active before %s", cp.address, cp.startAt)
+	}
+	if !cp.expiredAt.IsZero() && now.After(cp.expiredAt) {
+		return fmt.Errorf("coupon %s expired at %s", cp.address, cp.expiredAt)
+	}
+	if cp.passcodeHash != "" {
+		sum := sha256.Sum256([]byte(passcode))
+		if hex.EncodeToString(sum[:]) != cp.passcodeHash {
+			return fmt.Errorf("invalid passcode")
+		}
+	}
+	if cp.maxRedemptions > 0 && cp.totalRedemptions >= cp.maxRedemptions {
+		return fmt.Errorf("coupon %s reached its max redemptions", cp.address)
+	}
+	if cp.perUserLimit > 0 && cp.redemptions[user] >= cp.perUserLimit {
+		return fmt.Errorf("user %s reached the coupon limit", user)
+	}
+	if cp.minOrder != "" {
+		minOrder, _ := parseAmount(cp.minOrder)
+		if orderAmount.Cmp(minOrder) < 0 {
+			return fmt.Errorf("order amount %s is below the minimum %s", orderAmount, minOrder)
+		}
+	}
+	return nil
+}
+
+func (n *Node) getCouponState(c call, out *output) error {
+	switch c.method {
+	case couponV1.METHOD_GET_COUPON:
+		cp, ok := n.coupons[c.to]
+		if !ok {
+			return fmt.Errorf("coupon not found: %s", c.to)
+		}
+		out.state(cp.object())
+		return nil
+
+	case couponV1.METHOD_LIST_COUPONS:
+		var in struct {
+			Owner        string `json:"owner"`
+			TokenAddress string `json:"token_address"`
+			ProgramType  string `json:"program_type"`
+			Paused       *bool  `json:"paused"`
+			Page         int    `json:"page"`
+			Limit        int    `json:"limit"`
+			Ascending    bool   `json:"ascending"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		coupons := make([]object, 0)
+		for _, address := range n.couponOrder {
+			cp := n.coupons[address]
+			if (in.Owner != "" && cp.owner != in.Owner) ||
+				(in.TokenAddress != "" && cp.tokenAddress != in.TokenAddress) ||
+				(in.ProgramType != "" && cp.discountType != in.ProgramType) ||
+				(in.Paused != nil && cp.paused != *in.Paused) {
+				continue
+			}
+			coupons = append(coupons, cp.object())
+		}
+		out.state(paginate(coupons, in.Page, in.Limit, in.Ascending))
+		return nil
+	}
+
+	return fmt.Errorf("coupon method not supported by the fake node: %s", c.method)
+}
@@ -0,0 +1,119 @@
+package client_2financetest_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/2Finance-Labs/go-client-2finance/client_2finance/client_2financetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/couponV1"
+	couponV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/couponV1/domain"
+	tokenV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
+)
+
+func TestNode_CouponFlow(t *testing.T) {
+	owner := newKeySigner(t)
+	customer := newKeySigner(t)
+
+	node := client_2financetest.NewNode()
+	now := time.Now().UTC()
+	node.Now = func() time.Time { return now }
+
+	c := newClient(t, node, owner)
+	asCustomer := newClient(t, node, customer)
+
+	passcode := "client_2financetest"
+	sum := sha256.Sum256([]byte(passcode))
+
+	couponAddress := deployContract(t, c, couponV1.COUPON_CONTRACT_V1)
+	coupon, err := client2f.DecodeCoupon(c.AddCoupon(
+		couponAddress,
+		couponV1Domain.DISCOUNT_TYPE_PERCENTAGE,
+		"1000",
+		"",
+		"50",
+		now.Add(-time.Minute),
+		now.Add(time.Hour),
+		false,
+		true,
+		10,
+		1,
+		hex.EncodeToString(sum[:]),
+		owner.publicKey,
+		"CPN",
+		"Test Coupon",
+		"2",
+		"coupon created by client_2financetest tests",
+		"https://example.com/image.png",
+		"https://example.com",
+		map[string]string{},
+		map[string]string{},
+		map[string]string{},
+		"2Finance Test",
+		"https://example.com",
+		"https://example.com/asset.glb",
+	))
+	if err != nil {
+		t.Fatalf("AddCoupon: %v", err)
+	}
+	require.Equal(t, couponAddress, coupon.Address)
+	require.NotEmpty(t, coupon.TokenAddress, "voucher token")
+
+	// Only the owner issues vouchers.
+	var contractErr *client2f.ContractError
+	_, err = asCustomer.IssueVoucher(couponAddress, customer.publicKey, "1")
+	require.ErrorAs(t, err, &contractErr)
+
+	issued, err := client2f.DecodeIssuedVoucher(c.IssueVoucher(couponAddress, customer.publicKey, "1"))
+	if err != nil {
+		t.Fatalf("IssueVoucher: %v", err)
+	}
+	assert.Equal(t, customer.publicKey, issued.ToAddress)
+
+	vouchers, err := client2f.DecodeTokenBalances(c.ListTokenBalances(coupon.TokenAddress, customer.publicKey, tokenV1Domain.NON_FUNGIBLE, 1, 10, true))
+	if err != nil {
+		t.Fatalf("ListTokenBalances: %v", err)
+	}
+	require.Len(t, vouchers, 1, "customer vouchers")
+	voucher := vouchers[0].TokenUUID
+
+	// Vouchers are not redeemable with a wrong passcode, below the minimum
+	// order or while the coupon is paused.
+	_, err = asCustomer.RedeemVoucher(couponAddress, "100", "wrong", voucher)
+	require.ErrorAs(t, err, &contractErr)
+	_, err = asCustomer.RedeemVoucher(couponAddress, "49", passcode, voucher)
+	require.ErrorAs(t, err, &contractErr)
+
+	_, err = c.PauseCoupon(couponAddress, true)
+	require.NoError(t, err)
+	_, err = asCustomer.RedeemVoucher(couponAddress, "100", passcode, voucher)
+	require.ErrorAs(t, err, &contractErr)
+	_, err = c.UnpauseCoupon(couponAddress, false)
+	require.NoError(t, err)
+
+	redeemed, err := client2f.DecodeRedeemedVoucher(asCustomer.RedeemVoucher(couponAddress, "100", passcode, voucher))
+	if err != nil {
+		t.Fatalf("RedeemVoucher: %v", err)
+	}
+	assert.Equal(t, customer.publicKey, redeemed.UserAddress)
+	assert.Equal(t, "10", redeemed.DiscountAmount, "10% of 100")
+	assert.Equal(t, voucher, redeemed.VoucherUUID)
+
+	// The voucher is burned and the per-user limit is reached.
+	_, err = asCustomer.RedeemVoucher(couponAddress, "100", passcode, voucher)
+	require.ErrorAs(t, err, &contractErr)
+
+	// Past its expiry the coupon is no longer redeemable.
+	_, err = c.IssueVoucher(couponAddress, owner.publicKey, "1")
+	require.NoError(t, err)
+	now = now.Add(2 * time.Hour)
+	ownerVouchers, err := client2f.DecodeTokenBalances(c.ListTokenBalances(coupon.TokenAddress, owner.publicKey, tokenV1Domain.NON_FUNGIBLE, 1, 10, true))
+	require.NoError(t, err)
+	require.NotEmpty(t, ownerVouchers)
+	_, err = c.RedeemVoucher(couponAddress, "100", passcode, ownerVouchers[0].TokenUUID)
+	require.ErrorAs(t, err, &contractErr)
+}
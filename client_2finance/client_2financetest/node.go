@@ -0,0 +1,417 @@
+// Package client_2financetest provides an in-memory fake 2Finance node for
+// testing code built on client_2finance without a broker or a chain.
+//
+// A Node is a client_2finance.Transport. It answers the same request methods
+// and response envelopes as a real node and simulates the wallet, token
+// (fungible and non-fungible), payment and coupon contracts closely enough for
+// the Client2FinanceNetwork methods to run end to end:
+//
+//	node := client_2financetest.NewNode()
+//	c, err := node.NewClient(client_2finance.WithWalletManager(wm))
+//
+// Transactions must be signed by their sender over the network digest. Every
+// successful transaction is committed in its own block. Token transfer fees
+// and the contracts not listed above are not simulated; calls to them are
+// answered with an error response.
+package client_2financetest
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"gitlab.com/2finance/2finance-network/blockchain/block"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/contractV1"
+	contractV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/contractV1/domain"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/couponV1"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/walletV1"
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+	blockchainLog "gitlab.com/2finance/2finance-network/blockchain/log"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+	"gitlab.com/2finance/2finance-network/blockchain/virtualmachine"
+	"gitlab.com/2finance/2finance-network/infra/event"
+)
+
+// Node is an in-memory 2Finance node. It is safe for concurrent use; requests
+// are executed one at a time.
+type Node struct {
+	// Now returns the node time used for timestamps and expiry checks.
+	// It defaults to time.Now and may be replaced before the node is used.
+	Now func() time.Time
+
+	mu sync.Mutex
+
+	contracts map[string]string // address -> contract version
+
+	// Records by address. The order slices keep the addresses in creation
+	// order so lists are stable.
+	wallets      map[string]*walletRecord
+	tokens       map[string]*tokenRecord
+	tokenOrder   []string
+	payments     map[string]*paymentRecord
+	paymentOrder []string
+	coupons      map[string]*couponRecord
+	couponOrder  []string
+
+	transactions []transaction.Transaction
+	txHashes     map[string]bool
+	logs         []logRecord
+	blocks       []blockRecord
+}
+
+// NewNode returns an empty node.
+func NewNode() *Node {
+	return &Node{
+		Now:       time.Now,
+		contracts: make(map[string]string),
+		wallets:   make(map[string]*walletRecord),
+		tokens:    make(map[string]*tokenRecord),
+		payments:  make(map[string]*paymentRecord),
+		coupons:   make(map[string]*couponRecord),
+		txHashes:  make(map[string]bool),
+	}
+}
+
+// NewClient returns a client talking to n. The client defaults to the testnet
+// chain ID; opts are applied after that default.
+func (n *Node) NewClient(opts ...client2f.Option) (client2f.Client2FinanceNetwork, error) {
+	opts = append([]client2f.Option{client2f.WithChainID(client2f.ChainIDTestnet)}, opts...)
+	return client2f.NewWithTransport(n, opts...)
+}
+
+// RoundTrip implements client_2finance.Transport. Contract failures are
+// answered with an error response, like a real node does.
+func (n *Node) RoundTrip(ctx context.Context, _ string, request event.RequestPayload) (event.ResponsePayload, error) {
+	if err := ctx.Err(); err != nil {
+		return event.ResponsePayload{}, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	data, err := n.handle(request)
+	if err != nil {
+		return event.ResponsePayload{
+			Status:  event.RESPONSE_STATUS_ERROR,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return event.ResponsePayload{Data: data}, nil
+}
+
+// Transactions returns every transaction committed so far, oldest first.
+func (n *Node) Transactions() []transaction.Transaction {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]transaction.Transaction(nil), n.transactions...)
+}
+
+func (n *Node) handle(request event.RequestPayload) (interface{}, error) {
+	switch request.Method {
+	case virtualmachine.REQUEST_METHOD_SEND:
+		tx, err := decodeParams[transaction.Transaction](request.Params)
+		if err != nil {
+			return nil, err
+		}
+		return n.send(tx)
+
+	case virtualmachine.REQUEST_METHOD_GET_STATE:
+		in, err := decodeParams[transaction.TransactionInput](request.Params)
+		if err != nil {
+			return nil, err
+		}
+		return n.getState(in)
+
+	case virtualmachine.REQUEST_METHOD_GET_TRANSACTIONS:
+		in, err := decodeParams[transaction.TransactionInput](request.Params)
+		if err != nil {
+			return nil, err
+		}
+		return n.listTransactions(in), nil
+
+	case virtualmachine.REQUEST_METHOD_GET_LOGS:
+		in, err := decodeParams[blockchainLog.LogParams](request.Params)
+		if err != nil {
+			return nil, err
+		}
+		return n.listLogs(in)
+
+	case virtualmachine.REQUEST_METHOD_GET_BLOCKS:
+		in, err := decodeParams[block.BlockParams](request.Params)
+		if err != nil {
+			return nil, err
+		}
+		return n.listBlocks(in)
+	}
+
+	return nil, fmt.Errorf("unsupported request method: %s", request.Method)
+}
+
+// call is a transaction being executed.
+type call struct {
+	from   string
+	to     string
+	method string
+	data   json.RawMessage
+	hash   string
+	now    time.Time
+}
+
+// decode unmarshals the transaction data into v.
+func (c call) decode(v interface{}) error {
+	if len(c.data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(c.data, v); err != nil {
+		return fmt.Errorf("invalid data for %s: %w", c.method, err)
+	}
+	return nil
+}
+
+func (n *Node) send(tx transaction.Transaction) (types.ContractOutput, error) {
+	if err := verifySignature(tx); err != nil {
+		return types.ContractOutput{}, err
+	}
+	if n.txHashes[tx.Hash] {
+		return types.ContractOutput{}, fmt.Errorf("transaction already exists: %s", tx.Hash)
+	}
+
+	c := call{
+		from:   tx.From,
+		to:     tx.To,
+		method: tx.Method,
+		data:   tx.Data,
+		hash:   tx.Hash,
+		now:    n.Now().UTC(),
+	}
+
+	out := &output{}
+	if err := n.execute(c, out); err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	n.txHashes[tx.Hash] = true
+	n.transactions = append(n.transactions, tx)
+	n.commit(tx, out, c.now)
+
+	return out.contractOutput(c.hash)
+}
+
+// verifySignature checks that tx is signed by its sender over the network
+// digest of its content, as a real node does before executing it.
+func verifySignature(tx transaction.Transaction) error {
+	if tx.Signature == "" || tx.Hash == "" {
+		return fmt.Errorf("transaction is not signed")
+	}
+
+	digest, err := wallet_manager.NetworkTransactionDigest(&tx)
+	if err != nil {
+		return fmt.Errorf("failed to hash transaction: %w", err)
+	}
+	if tx.Hash != hex.EncodeToString(digest) {
+		return fmt.Errorf("transaction hash does not match its content: %s", tx.Hash)
+	}
+
+	publicKey, err := hex.DecodeString(tx.From)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid from address: %s", tx.From)
+	}
+	signature, err := hex.DecodeString(tx.Signature)
+	if err != nil || !ed25519.Verify(publicKey, digest, signature) {
+		return fmt.Errorf("invalid signature for transaction %s", tx.Hash)
+	}
+
+	return nil
+}
+
+func (n *Node) execute(c call, out *output) error {
+	switch c.method {
+	case contractV1.METHOD_DEPLOY_CONTRACT:
+		address, err := newAddress()
+		if err != nil {
+			return err
+		}
+		return n.deploy(c, address, out)
+	case contractV1.METHOD_DEPLOY_CONTRACT2:
+		return n.deploy(c, c.to, out)
+	}
+
+	version, ok := n.contracts[c.to]
+	if !ok {
+		return fmt.Errorf("contract not found: %s", c.to)
+	}
+
+	switch version {
+	case walletV1.WALLET_CONTRACT_V1:
+		return n.sendWallet(c, out)
+	case tokenV1.TOKEN_CONTRACT_V1:
+		return n.sendToken(c, out)
+	case paymentV1.PAYMENT_CONTRACT_V1:
+		return n.sendPayment(c, out)
+	case couponV1.COUPON_CONTRACT_V1:
+		return n.sendCoupon(c, out)
+	}
+
+	return fmt.Errorf("contract %s is not supported by the fake node", version)
+}
+
+func (n *Node) deploy(c call, address string, out *output) error {
+	var in struct {
+		ContractVersion string `json:"contract_version"`
+	}
+	if err := c.decode(&in); err != nil {
+		return err
+	}
+	if in.ContractVersion == "" {
+		return fmt.Errorf("contract version is required")
+	}
+	if _, exists := n.contracts[address]; exists {
+		return fmt.Errorf("contract already deployed at %s", address)
+	}
+
+	n.contracts[address] = in.ContractVersion
+	out.log(address, contractV1Domain.DEPLOYED_CONTRACT_LOG, object{
+		"address":          address,
+		"contract_version": in.ContractVersion,
+		"owner":            c.from,
+		"created_at":       c.now,
+	})
+
+	return nil
+}
+
+func (n *Node) getState(in transaction.TransactionInput) (types.ContractOutput, error) {
+	c := call{
+		to:     in.To,
+		method: in.Method,
+		data:   json.RawMessage(in.Data),
+		now:    n.Now().UTC(),
+	}
+
+	version := n.contracts[in.To]
+	if in.To == "" {
+		// Reads not bound to a contract name the contract family instead.
+		var filter struct {
+			ContractVersion string `json:"contract_version"`
+		}
+		if err := c.decode(&filter); err != nil {
+			return types.ContractOutput{}, err
+		}
+		version = filter.ContractVersion
+	}
+
+	out := &output{}
+	var err error
+	switch version {
+	case walletV1.WALLET_CONTRACT_V1:
+		err = n.getWalletState(c, out)
+	case tokenV1.TOKEN_CONTRACT_V1:
+		err = n.getTokenState(c, out)
+	case paymentV1.PAYMENT_CONTRACT_V1:
+		err = n.getPaymentState(c, out)
+	case couponV1.COUPON_CONTRACT_V1:
+		err = n.getCouponState(c, out)
+	case "":
+		err = fmt.Errorf("contract not found: %s", in.To)
+	default:
+		err = fmt.Errorf("contract %s is not supported by the fake node", version)
+	}
+	if err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	return out.contractOutput("")
+}
+
+func (n *Node) listTransactions(in transaction.TransactionInput) []transaction.Transaction {
+	matches := make([]transaction.Transaction, 0)
+	for _, tx := range n.transactions {
+		if in.From != "" && tx.From != in.From {
+			continue
+		}
+		if in.To != "" && tx.To != in.To {
+			continue
+		}
+		if in.Hash != "" && tx.Hash != in.Hash {
+			continue
+		}
+		matches = append(matches, tx)
+	}
+
+	return paginate(matches, in.Page, in.Limit, in.Ascending)
+}
+
+// newAddress returns a fresh address in the format the network uses for
+// contracts and wallets.
+func newAddress() (string, error) {
+	pub, _, err := keys.GenerateEd25519KeyPair()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate address: %w", err)
+	}
+	return keys.PublicKeyToHex(pub), nil
+}
+
+// decodeParams converts request params to T. In-process clients hand the
+// value over as is; anything else goes through its JSON form.
+func decodeParams[T any](params interface{}) (T, error) {
+	var v T
+
+	switch p := params.(type) {
+	case T:
+		return p, nil
+	case *T:
+		if p != nil {
+			return *p, nil
+		}
+		return v, fmt.Errorf("request params are empty")
+	}
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return v, fmt.Errorf("failed to marshal request params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return v, fmt.Errorf("failed to unmarshal request params: %w", err)
+	}
+
+	return v, nil
+}
+
+// paginate returns the page of items selected by page (1-based) and limit.
+// A zero page or limit returns everything.
+func paginate[T any](items []T, page, limit int, ascending bool) []T {
+	if !ascending {
+		reversed := make([]T, len(items))
+		for i, item := range items {
+			reversed[len(items)-1-i] = item
+		}
+		items = reversed
+	}
+
+	if page <= 0 || limit <= 0 {
+		return items
+	}
+
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return items[:0]
+	}
+
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end]
+}
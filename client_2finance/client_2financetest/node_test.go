@@ -0,0 +1,180 @@
+package client_2financetest_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/2Finance-Labs/go-client-2finance/client_2finance/client_2financetest"
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
+	tokenV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/walletV1"
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/utils"
+	"gitlab.com/2finance/2finance-network/blockchain/virtualmachine"
+	"gitlab.com/2finance/2finance-network/infra/event"
+)
+
+// keySigner signs with a private key held in memory, the way the network
+// does.
+type keySigner struct {
+	publicKey  string
+	privateKey string
+}
+
+func newKeySigner(t *testing.T) keySigner {
+	t.Helper()
+
+	publicKey, privateKey, err := keys.GenerateEd25519KeyPair()
+	if err != nil {
+		t.Fatalf("GenerateEd25519KeyPair: %v", err)
+	}
+
+	return keySigner{
+		publicKey:  keys.PublicKeyToHex(publicKey),
+		privateKey: keys.PrivateKeyToHex(privateKey),
+	}
+}
+
+func (s keySigner) GetPublicKey() string {
+	return s.publicKey
+}
+
+func (s keySigner) IsUnlocked() bool {
+	return true
+}
+
+func (s keySigner) SignTransaction(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error) {
+	tx, err := wallet_manager.NewUnsignedTransaction(chainId, from, to, method, data, version, uuid7)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction.SignTransactionHexKey(s.privateKey, tx)
+}
+
+// impostor claims the public key of another account but signs with its own
+// key.
+type impostor struct {
+	keySigner
+	claimed string
+}
+
+func (s impostor) GetPublicKey() string {
+	return s.claimed
+}
+
+func newClient(t *testing.T, node *client_2financetest.Node, signer keySigner) client2f.Client2FinanceNetwork {
+	t.Helper()
+
+	c, err := node.NewClient(client2f.WithTransactionSigner(signer))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	return c
+}
+
+func deployContract(t *testing.T, c client2f.Client2FinanceNetwork, contractVersion string) string {
+	t.Helper()
+
+	contract, err := client2f.DecodeDeployedContract(c.DeployContract1(contractVersion))
+	if err != nil {
+		t.Fatalf("DeployContract1(%s): %v", contractVersion, err)
+	}
+	require.NotEmpty(t, contract.Address)
+
+	return contract.Address
+}
+
+// addToken deploys a transferable token owned by owner with totalSupply
+// units minted to the owner.
+func addToken(t *testing.T, c client2f.Client2FinanceNetwork, owner, tokenType, totalSupply string) tokenV1Domain.Token {
+	t.Helper()
+
+	address := deployContract(t, c, tokenV1.TOKEN_CONTRACT_V1)
+
+	token, err := client2f.DecodeToken(c.AddToken(
+		address,
+		"2FT",
+		"2Finance Test",
+		0,
+		totalSupply,
+		"token created by client_2financetest tests",
+		owner,
+		"https://example.com/image.png",
+		"https://example.com",
+		map[string]string{},
+		map[string]string{},
+		map[string]string{},
+		"2Finance Test",
+		"https://example.com",
+		map[string]bool{},
+		map[string]bool{},
+		map[string]bool{},
+		[]map[string]interface{}{},
+		owner,
+		false,
+		false,
+		false,
+		false,
+		time.Time{},
+		"https://example.com/asset.glb",
+		tokenType,
+		true,
+		tokenV1Domain.TOKEN_ASSET_TYPE,
+	))
+	if err != nil {
+		t.Fatalf("AddToken: %v", err)
+	}
+	require.Equal(t, address, token.Address)
+
+	return token
+}
+
+func TestNode_VerifiesSignatures(t *testing.T) {
+	owner := newKeySigner(t)
+	node := client_2financetest.NewNode()
+
+	// A transaction signed by its sender is accepted.
+	c := newClient(t, node, owner)
+	deployContract(t, c, walletV1.WALLET_CONTRACT_V1)
+
+	// One signed by another key than the sender's is not.
+	forger, err := node.NewClient(client2f.WithTransactionSigner(impostor{keySigner: newKeySigner(t), claimed: owner.publicKey}))
+	require.NoError(t, err)
+	_, err = forger.DeployContract1(walletV1.WALLET_CONTRACT_V1)
+	require.ErrorContains(t, err, "invalid signature")
+
+	// Nor one changed after it was signed.
+	tampering := client2f.TransportFunc(func(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error) {
+		if request.Method == virtualmachine.REQUEST_METHOD_SEND {
+			raw, err := json.Marshal(request.Params)
+			if err != nil {
+				return event.ResponsePayload{}, err
+			}
+			var tx transaction.Transaction
+			if err := json.Unmarshal(raw, &tx); err != nil {
+				return event.ResponsePayload{}, err
+			}
+			tx.Data = json.RawMessage(`{"contract_version":"` + tokenV1.TOKEN_CONTRACT_V1 + `"}`)
+			request.Params = tx
+		}
+		return node.RoundTrip(ctx, replyTo, request)
+	})
+	tampered, err := client2f.NewWithTransport(tampering,
+		client2f.WithChainID(client2f.ChainIDTestnet),
+		client2f.WithTransactionSigner(owner),
+	)
+	require.NoError(t, err)
+	_, err = tampered.DeployContract1(walletV1.WALLET_CONTRACT_V1)
+	require.ErrorContains(t, err, "hash does not match")
+
+	assert.Len(t, node.Transactions(), 1, "only the genuine transaction is committed")
+}
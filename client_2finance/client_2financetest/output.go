@@ -0,0 +1,284 @@
+package client_2financetest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/block"
+	blockchainLog "gitlab.com/2finance/2finance-network/blockchain/log"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+)
+
+// object is an event or state payload. Keys follow the JSON names the
+// network uses, which are the same ones the client sends in requests.
+type object = map[string]interface{}
+
+// output collects what a call produced, in the order it was produced.
+type output struct {
+	logs      []logRecord
+	states    []interface{}
+	delegated []*output
+}
+
+type logRecord struct {
+	logType         string
+	contractAddress string
+	transactionHash string
+	logIndex        uint
+	event           interface{}
+}
+
+type blockRecord struct {
+	number       uint64
+	hash         string
+	previousHash string
+	timestamp    time.Time
+	transactions []transaction.Transaction
+}
+
+func (o *output) log(contractAddress, logType string, event interface{}) {
+	o.logs = append(o.logs, logRecord{
+		logType:         logType,
+		contractAddress: contractAddress,
+		event:           event,
+	})
+}
+
+func (o *output) state(obj interface{}) {
+	o.states = append(o.states, obj)
+}
+
+// delegate returns the output of a call the current contract makes into
+// another contract.
+func (o *output) delegate() *output {
+	d := &output{}
+	o.delegated = append(o.delegated, d)
+	return d
+}
+
+// commit stores the logs of out and seals tx in a new block.
+func (n *Node) commit(tx transaction.Transaction, out *output, now time.Time) {
+	var index uint
+	var stamp func(o *output)
+	stamp = func(o *output) {
+		for i := range o.logs {
+			o.logs[i].transactionHash = tx.Hash
+			o.logs[i].logIndex = index
+			index++
+			n.logs = append(n.logs, o.logs[i])
+		}
+		for _, d := range o.delegated {
+			stamp(d)
+		}
+	}
+	stamp(out)
+
+	previousHash := ""
+	if len(n.blocks) > 0 {
+		previousHash = n.blocks[len(n.blocks)-1].hash
+	}
+	number := uint64(len(n.blocks) + 1)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", number, previousHash, tx.Hash)))
+
+	n.blocks = append(n.blocks, blockRecord{
+		number:       number,
+		hash:         hex.EncodeToString(sum[:]),
+		previousHash: previousHash,
+		timestamp:    now,
+		transactions: []transaction.Transaction{tx},
+	})
+
+}
+
+func (n *Node) listLogs(in blockchainLog.LogParams) ([]blockchainLog.Log, error) {
+	types := make(map[string]bool, len(in.LogType))
+	for _, t := range in.LogType {
+		types[t] = true
+	}
+
+	matches := make([]logRecord, 0)
+	for _, lg := range n.logs {
+		if len(types) > 0 && !types[lg.logType] {
+			continue
+		}
+		if in.TransactionHash != "" && lg.transactionHash != in.TransactionHash {
+			continue
+		}
+		if in.ContractAddress != "" && lg.contractAddress != in.ContractAddress {
+			continue
+		}
+		matches = append(matches, lg)
+	}
+
+	matches = paginate(matches, in.Page, in.Limit, in.Ascending)
+
+	logs := make([]blockchainLog.Log, 0, len(matches))
+	for _, lg := range matches {
+		l, err := lg.build()
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+
+	return logs, nil
+}
+
+func (n *Node) listBlocks(in block.BlockParams) ([]block.Block, error) {
+	matches := make([]blockRecord, 0)
+	for _, b := range n.blocks {
+		if in.Number != 0 && b.number != in.Number {
+			continue
+		}
+		if in.Hash != "" && b.hash != in.Hash {
+			continue
+		}
+		if in.PreviousHash != "" && b.previousHash != in.PreviousHash {
+			continue
+		}
+		matches = append(matches, b)
+	}
+
+	matches = paginate(matches, in.Page, in.Limit, in.Ascending)
+
+	blocks := make([]block.Block, 0, len(matches))
+	for _, b := range matches {
+		blk, err := b.build()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, blk)
+	}
+
+	return blocks, nil
+}
+
+func (b blockRecord) build() (block.Block, error) {
+	var blk block.Block
+	v := reflect.ValueOf(&blk).Elem()
+	for name, value := range map[string]interface{}{
+		"Number":       b.number,
+		"Hash":         b.hash,
+		"PreviousHash": b.previousHash,
+		"Timestamp":    b.timestamp,
+		"Transactions": b.transactions,
+	} {
+		if err := setField(v, name, value); err != nil {
+			return block.Block{}, err
+		}
+	}
+	return blk, nil
+}
+
+func (lg logRecord) build() (blockchainLog.Log, error) {
+	var l blockchainLog.Log
+	v := reflect.ValueOf(&l).Elem()
+	for name, value := range map[string]interface{}{
+		"LogType":         lg.logType,
+		"LogIndex":        lg.logIndex,
+		"TransactionHash": lg.transactionHash,
+		"ContractAddress": lg.contractAddress,
+		"Event":           lg.event,
+	} {
+		if err := setField(v, name, value); err != nil {
+			return blockchainLog.Log{}, err
+		}
+	}
+	return l, nil
+}
+
+// contractOutput converts o to the ContractOutput the client decodes.
+func (o *output) contractOutput(transactionHash string) (types.ContractOutput, error) {
+	var out types.ContractOutput
+	v := reflect.ValueOf(&out).Elem()
+
+	logs := make([]blockchainLog.Log, 0, len(o.logs))
+	for _, lg := range o.logs {
+		if lg.transactionHash == "" {
+			lg.transactionHash = transactionHash
+		}
+		l, err := lg.build()
+		if err != nil {
+			return types.ContractOutput{}, err
+		}
+		logs = append(logs, l)
+	}
+	if err := setField(v, "Logs", logs); err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	states := reflect.MakeSlice(v.FieldByName("States").Type(), 0, len(o.states))
+	for _, obj := range o.states {
+		state := reflect.New(states.Type().Elem()).Elem()
+		if err := setField(state, "Object", obj); err != nil {
+			return types.ContractOutput{}, err
+		}
+		states = reflect.Append(states, state)
+	}
+	v.FieldByName("States").Set(states)
+
+	if len(o.delegated) > 0 {
+		delegated := make([]types.ContractOutput, 0, len(o.delegated))
+		for _, d := range o.delegated {
+			dOut, err := d.contractOutput(transactionHash)
+			if err != nil {
+				return types.ContractOutput{}, err
+			}
+			delegated = append(delegated, dOut)
+		}
+		if err := setField(v, "DelegatedCall", delegated); err != nil {
+			return types.ContractOutput{}, err
+		}
+	}
+
+	return out, nil
+}
+
+// setField sets the named field of the struct v to value, converting it
+// through JSON when the types differ. Fields the struct does not have are
+// skipped.
+func setField(v reflect.Value, name string, value interface{}) error {
+	field := v.FieldByName(name)
+	if !field.IsValid() || !field.CanSet() {
+		return nil
+	}
+
+	converted, err := convert(value, field.Type())
+	if err != nil {
+		return fmt.Errorf("failed to set %s.%s: %w", v.Type().Name(), name, err)
+	}
+	field.Set(converted)
+
+	return nil
+}
+
+func convert(value interface{}, typ reflect.Type) (reflect.Value, error) {
+	rv := reflect.ValueOf(value)
+	if rv.IsValid() && rv.Type().AssignableTo(typ) {
+		return rv, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	switch {
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		return reflect.ValueOf(raw).Convert(typ), nil
+	case typ.Kind() == reflect.String && rv.Kind() != reflect.String:
+		return reflect.ValueOf(string(raw)).Convert(typ), nil
+	}
+
+	ptr := reflect.New(typ)
+	if err := json.Unmarshal(raw, ptr.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return ptr.Elem(), nil
+}
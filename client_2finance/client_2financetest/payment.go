@@ -0,0 +1,301 @@
+package client_2financetest
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1"
+	paymentV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1/domain"
+)
+
+// paymentRecord is a payment held by a payment contract. Authorized funds
+// are held in escrow by the payment address until they are captured or
+// voided.
+type paymentRecord struct {
+	address        string
+	owner          string
+	tokenAddress   string
+	orderId        string
+	payer          string
+	payee          string
+	amount         *big.Int
+	capturedAmount *big.Int
+	refundedAmount *big.Int
+	status         string
+	paused         bool
+	expiredAt      time.Time
+	hash           string
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+func (p *paymentRecord) object() object {
+	obj := object{
+		"address":         p.address,
+		"owner":           p.owner,
+		"token_address":   p.tokenAddress,
+		"order_id":        p.orderId,
+		"payer":           p.payer,
+		"payee":           p.payee,
+		"amount":          p.amount.String(),
+		"captured_amount": p.capturedAmount.String(),
+		"refunded_amount": p.refundedAmount.String(),
+		"status":          p.status,
+		"paused":          p.paused,
+		"hash":            p.hash,
+		"created_at":      p.createdAt,
+		"updated_at":      p.updatedAt,
+	}
+	if !p.expiredAt.IsZero() {
+		obj["expired_at"] = p.expiredAt
+	}
+	return obj
+}
+
+func (n *Node) sendPayment(c call, out *output) error {
+	switch c.method {
+	case paymentV1.METHOD_CREATE_PAYMENT, paymentV1.METHOD_DIRECT_PAY:
+		p, err := n.newPayment(c)
+		if err != nil {
+			return err
+		}
+		out.log(p.address, paymentV1Domain.PAYMENT_CREATED_LOG, p.object())
+		if c.method == paymentV1.METHOD_CREATE_PAYMENT {
+			n.storePayment(p)
+			return nil
+		}
+
+		if c.from != p.payer {
+			return fmt.Errorf("only the payer can pay directly: %s", c.from)
+		}
+		if err := n.moveFunds(p, p.payer, p.payee, p.amount, c.now, out); err != nil {
+			return err
+		}
+		n.storePayment(p)
+		p.setStatus(paymentV1Domain.STATUS_AUTHORIZED, c.now)
+		out.log(p.address, paymentV1Domain.PAYMENT_AUTHORIZED_LOG, p.object())
+		p.capturedAmount.Set(p.amount)
+		p.setStatus(paymentV1Domain.STATUS_CAPTURED, c.now)
+		out.log(p.address, paymentV1Domain.PAYMENT_CAPTURED_LOG, p.object())
+		return nil
+	}
+
+	p, ok := n.payments[c.to]
+	if !ok {
+		return fmt.Errorf("payment not found: %s", c.to)
+	}
+
+	switch c.method {
+	case paymentV1.METHOD_PAUSE_PAYMENT, paymentV1.METHOD_UNPAUSE_PAYMENT:
+		if c.from != p.owner && c.from != p.payer && c.from != p.payee {
+			return fmt.Errorf("only a party to the payment can pause it: %s", c.from)
+		}
+		p.paused = c.method == paymentV1.METHOD_PAUSE_PAYMENT
+		p.updatedAt = c.now
+		logType := paymentV1Domain.PAYMENT_PAUSED_LOG
+		if !p.paused {
+			logType = paymentV1Domain.PAYMENT_UNPAUSED_LOG
+		}
+		out.log(p.address, logType, p.object())
+		return nil
+	}
+
+	if p.paused {
+		return fmt.Errorf("payment %s is paused", p.address)
+	}
+
+	switch c.method {
+	case paymentV1.METHOD_AUTHORIZE_PAYMENT:
+		if c.from != p.payer {
+			return fmt.Errorf("only the payer can authorize the payment: %s", c.from)
+		}
+		if p.status != paymentV1Domain.STATUS_CREATED {
+			return fmt.Errorf("payment %s cannot be authorized in status %s", p.address, p.status)
+		}
+		if !p.expiredAt.IsZero() && c.now.After(p.expiredAt) {
+			return fmt.Errorf("payment %s expired at %s", p.address, p.expiredAt)
+		}
+		if err := n.moveFunds(p, p.payer, p.address, p.amount, c.now, out); err != nil {
+			return err
+		}
+		p.setStatus(paymentV1Domain.STATUS_AUTHORIZED, c.now)
+		out.log(p.address, paymentV1Domain.PAYMENT_AUTHORIZED_LOG, p.object())
+
+	case paymentV1.METHOD_CAPTURE_PAYMENT:
+		if c.from != p.payee && c.from != p.owner {
+			return fmt.Errorf("only the payee or the owner can capture the payment: %s", c.from)
+		}
+		if p.status != paymentV1Domain.STATUS_AUTHORIZED {
+			return fmt.Errorf("payment %s cannot be captured in status %s", p.address, p.status)
+		}
+		if err := n.moveFunds(p, p.address, p.payee, p.amount, c.now, out); err != nil {
+			return err
+		}
+		p.capturedAmount.Set(p.amount)
+		p.setStatus(paymentV1Domain.STATUS_CAPTURED, c.now)
+		out.log(p.address, paymentV1Domain.PAYMENT_CAPTURED_LOG, p.object())
+
+	case paymentV1.METHOD_REFUND_PAYMENT:
+		var in struct {
+			Amount string `json:"amount"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		if c.from != p.payee {
+			return fmt.Errorf("only the payee can refund the payment: %s", c.from)
+		}
+		if p.status != paymentV1Domain.STATUS_CAPTURED && p.status != paymentV1Domain.STATUS_REFUNDED {
+			return fmt.Errorf("payment %s cannot be refunded in status %s", p.address, p.status)
+		}
+		amount, err := parseAmount(in.Amount)
+		if err != nil {
+			return err
+		}
+		refundable := new(big.Int).Sub(p.capturedAmount, p.refundedAmount)
+		if amount.Sign() <= 0 || amount.Cmp(refundable) > 0 {
+			return fmt.Errorf("refund amount %s exceeds refundable amount %s", amount, refundable)
+		}
+		if err := n.moveFunds(p, p.payee, p.payer, amount, c.now, out); err != nil {
+			return err
+		}
+		p.refundedAmount.Add(p.refundedAmount, amount)
+		p.setStatus(paymentV1Domain.STATUS_REFUNDED, c.now)
+		out.log(p.address, paymentV1Domain.PAYMENT_REFUNDED_LOG, p.object())
+
+	case paymentV1.METHOD_VOID_PAYMENT:
+		if c.from != p.payer && c.from != p.owner {
+			return fmt.Errorf("only the payer or the owner can void the payment: %s", c.from)
+		}
+		switch p.status {
+		case paymentV1Domain.STATUS_AUTHORIZED:
+			if err := n.moveFunds(p, p.address, p.payer, p.amount, c.now, out); err != nil {
+				return err
+			}
+		case paymentV1Domain.STATUS_CREATED:
+		default:
+			return fmt.Errorf("payment %s cannot be voided in status %s", p.address, p.status)
+		}
+		p.setStatus(paymentV1Domain.STATUS_VOIDED, c.now)
+		out.log(p.address, paymentV1Domain.PAYMENT_VOIDED_LOG, p.object())
+
+	default:
+		return fmt.Errorf("payment method not supported by the fake node: %s", c.method)
+	}
+
+	return nil
+}
+
+// newPayment builds the payment created by c without storing it.
+func (n *Node) newPayment(c call) (*paymentRecord, error) {
+	var in struct {
+		Owner        string    `json:"owner"`
+		TokenAddress string    `json:"token_address"`
+		OrderId      string    `json:"order_id"`
+		Payer        string    `json:"payer"`
+		Payee        string    `json:"payee"`
+		Amount       string    `json:"amount"`
+		ExpiredAt    time.Time `json:"expired_at"`
+	}
+	if err := c.decode(&in); err != nil {
+		return nil, err
+	}
+	if _, exists := n.payments[c.to]; exists {
+		return nil, fmt.Errorf("payment already exists: %s", c.to)
+	}
+	t, ok := n.tokens[in.TokenAddress]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", in.TokenAddress)
+	}
+	if !t.fungible() {
+		return nil, fmt.Errorf("payments need a fungible token: %s", in.TokenAddress)
+	}
+	amount, err := parseAmount(in.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("amount must be greater than 0: %s", in.Amount)
+	}
+
+	return &paymentRecord{
+		address:        c.to,
+		owner:          in.Owner,
+		tokenAddress:   in.TokenAddress,
+		orderId:        in.OrderId,
+		payer:          in.Payer,
+		payee:          in.Payee,
+		amount:         amount,
+		capturedAmount: new(big.Int),
+		refundedAmount: new(big.Int),
+		status:         paymentV1Domain.STATUS_CREATED,
+		expiredAt:      in.ExpiredAt,
+		hash:           c.hash,
+		createdAt:      c.now,
+		updatedAt:      c.now,
+	}, nil
+}
+
+func (n *Node) storePayment(p *paymentRecord) {
+	n.payments[p.address] = p
+	n.paymentOrder = append(n.paymentOrder, p.address)
+}
+
+func (p *paymentRecord) setStatus(status string, now time.Time) {
+	p.status = status
+	p.updatedAt = now
+}
+
+// moveFunds transfers amount of the payment token, logging the transfer as a
+// call into the token contract.
+func (n *Node) moveFunds(p *paymentRecord, from, to string, amount *big.Int, now time.Time, out *output) error {
+	t, ok := n.tokens[p.tokenAddress]
+	if !ok {
+		return fmt.Errorf("token not found: %s", p.tokenAddress)
+	}
+	return t.transfer(from, to, amount.String(), nil, now, out.delegate())
+}
+
+func (n *Node) getPaymentState(c call, out *output) error {
+	switch c.method {
+	case paymentV1.METHOD_GET_PAYMENT:
+		p, ok := n.payments[c.to]
+		if !ok {
+			return fmt.Errorf("payment not found: %s", c.to)
+		}
+		out.state(p.object())
+		return nil
+
+	case paymentV1.METHOD_LIST_PAYMENTS:
+		var in struct {
+			OrderId      string `json:"order_id"`
+			TokenAddress string `json:"token_address"`
+			Status       string `json:"status"`
+			Payer        string `json:"payer"`
+			Payee        string `json:"payee"`
+			Page         int    `json:"page"`
+			Limit        int    `json:"limit"`
+			Ascending    bool   `json:"ascending"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		payments := make([]object, 0)
+		for _, address := range n.paymentOrder {
+			p := n.payments[address]
+			if (in.OrderId != "" && p.orderId != in.OrderId) ||
+				(in.TokenAddress != "" && p.tokenAddress != in.TokenAddress) ||
+				(in.Status != "" && p.status != in.Status) ||
+				(in.Payer != "" && p.payer != in.Payer) ||
+				(in.Payee != "" && p.payee != in.Payee) {
+				continue
+			}
+			payments = append(payments, p.object())
+		}
+		out.state(paginate(payments, in.Page, in.Limit, in.Ascending))
+		return nil
+	}
+
+	return fmt.Errorf("payment method not supported by the fake node: %s", c.method)
+}
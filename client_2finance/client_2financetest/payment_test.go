@@ -0,0 +1,153 @@
+package client_2financetest_test
+
+import (
+	"testing"
+	"time"
+
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/2Finance-Labs/go-client-2finance/client_2finance/client_2financetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1"
+	paymentV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1/domain"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1/inputs"
+	tokenV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
+)
+
+func TestNode_PaymentFlow(t *testing.T) {
+	owner := newKeySigner(t)
+	payer := newKeySigner(t)
+	payee := newKeySigner(t)
+
+	node := client_2financetest.NewNode()
+	c := newClient(t, node, owner)
+	asPayer := newClient(t, node, payer)
+	asPayee := newClient(t, node, payee)
+
+	tok := addToken(t, c, owner.publicKey, tokenV1Domain.FUNGIBLE, "1000")
+	_, err := c.TransferToken(tok.Address, payer.publicKey, "500", nil)
+	require.NoError(t, err)
+
+	paymentAddress := deployContract(t, c, paymentV1.PAYMENT_CONTRACT_V1)
+
+	created, err := client2f.DecodePayment(c.CreatePayment(inputs.InputCreate{
+		Address:      paymentAddress,
+		Owner:        owner.publicKey,
+		TokenAddress: tok.Address,
+		OrderId:      "order-1",
+		Payer:        payer.publicKey,
+		Payee:        payee.publicKey,
+		Amount:       "300",
+		ExpiredAt:    time.Now().Add(time.Hour),
+	}))
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	assert.Equal(t, paymentV1Domain.STATUS_CREATED, created.Status)
+
+	// Only the payer can authorize, and not while the payment is paused.
+	var contractErr *client2f.ContractError
+	_, err = asPayee.AuthorizePayment(inputs.InputAuthorize{Address: paymentAddress})
+	require.ErrorAs(t, err, &contractErr)
+
+	paused, err := client2f.DecodePayment(asPayer.PausePayment(inputs.InputPause{Address: paymentAddress, Paused: true}))
+	require.NoError(t, err)
+	assert.True(t, paused.Paused)
+
+	_, err = asPayer.AuthorizePayment(inputs.InputAuthorize{Address: paymentAddress})
+	require.ErrorAs(t, err, &contractErr)
+
+	_, err = asPayer.UnpausePayment(inputs.InputPause{Address: paymentAddress, Paused: false})
+	require.NoError(t, err)
+
+	authorized, err := client2f.DecodePayment(asPayer.AuthorizePayment(inputs.InputAuthorize{Address: paymentAddress}))
+	if err != nil {
+		t.Fatalf("AuthorizePayment: %v", err)
+	}
+	assert.Equal(t, paymentV1Domain.STATUS_AUTHORIZED, authorized.Status)
+
+	// The authorized amount is held in escrow by the payment.
+	assertBalance(t, c, tok.Address, payer.publicKey, "200")
+	assertBalance(t, c, tok.Address, paymentAddress, "300")
+
+	captured, err := client2f.DecodePayment(asPayee.CapturePayment(inputs.InputCapture{Address: paymentAddress}))
+	if err != nil {
+		t.Fatalf("CapturePayment: %v", err)
+	}
+	assert.Equal(t, paymentV1Domain.STATUS_CAPTURED, captured.Status)
+	assert.Equal(t, "300", captured.CapturedAmount)
+	assertBalance(t, c, tok.Address, payee.publicKey, "300")
+
+	// Refunds cannot exceed what was captured.
+	_, err = asPayee.RefundPayment(inputs.InputRefund{Address: paymentAddress, Amount: "301"})
+	require.ErrorAs(t, err, &contractErr)
+
+	refunded, err := client2f.DecodePayment(asPayee.RefundPayment(inputs.InputRefund{Address: paymentAddress, Amount: "100"}))
+	if err != nil {
+		t.Fatalf("RefundPayment: %v", err)
+	}
+	assert.Equal(t, paymentV1Domain.STATUS_REFUNDED, refunded.Status)
+	assertBalance(t, c, tok.Address, payer.publicKey, "300")
+	assertBalance(t, c, tok.Address, payee.publicKey, "200")
+
+	state, err := client2f.DecodePaymentState(c.GetPayment(paymentAddress))
+	if err != nil {
+		t.Fatalf("GetPayment: %v", err)
+	}
+	assert.Equal(t, "300", state.Amount)
+	assert.Equal(t, "100", state.RefundedAmount)
+	assert.Equal(t, paymentV1Domain.STATUS_REFUNDED, state.Status)
+}
+
+func TestNode_PaymentVoidReleasesEscrow(t *testing.T) {
+	owner := newKeySigner(t)
+	payer := newKeySigner(t)
+	payee := newKeySigner(t)
+
+	node := client_2financetest.NewNode()
+	c := newClient(t, node, owner)
+	asPayer := newClient(t, node, payer)
+
+	tok := addToken(t, c, owner.publicKey, tokenV1Domain.FUNGIBLE, "1000")
+	_, err := c.TransferToken(tok.Address, payer.publicKey, "500", nil)
+	require.NoError(t, err)
+
+	paymentAddress := deployContract(t, c, paymentV1.PAYMENT_CONTRACT_V1)
+	_, err = c.CreatePayment(inputs.InputCreate{
+		Address:      paymentAddress,
+		Owner:        owner.publicKey,
+		TokenAddress: tok.Address,
+		OrderId:      "order-2",
+		Payer:        payer.publicKey,
+		Payee:        payee.publicKey,
+		Amount:       "300",
+		ExpiredAt:    time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = asPayer.AuthorizePayment(inputs.InputAuthorize{Address: paymentAddress})
+	require.NoError(t, err)
+	assertBalance(t, c, tok.Address, payer.publicKey, "200")
+
+	voided, err := client2f.DecodePayment(asPayer.VoidPayment(inputs.InputVoidPayment{Address: paymentAddress}))
+	if err != nil {
+		t.Fatalf("VoidPayment: %v", err)
+	}
+	assert.Equal(t, paymentV1Domain.STATUS_VOIDED, voided.Status)
+	assertBalance(t, c, tok.Address, payer.publicKey, "500")
+
+	// A voided payment cannot be captured.
+	_, err = c.CapturePayment(inputs.InputCapture{Address: paymentAddress})
+	var contractErr *client2f.ContractError
+	require.ErrorAs(t, err, &contractErr)
+}
+
+func assertBalance(t *testing.T, c client2f.Client2FinanceNetwork, tokenAddress, owner, want string) {
+	t.Helper()
+
+	balance, err := client2f.DecodeTokenBalance(c.GetTokenBalance(tokenAddress, owner))
+	if err != nil {
+		t.Fatalf("GetTokenBalance(%s): %v", owner, err)
+	}
+	assert.Equal(t, want, balance.Amount, "balance of %s", owner)
+}
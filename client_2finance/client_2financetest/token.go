@@ -0,0 +1,904 @@
+package client_2financetest
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
+	tokenV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
+)
+
+type tokenRecord struct {
+	address string
+	owner   string
+
+	// metadata holds the descriptive fields as they were last sent.
+	metadata object
+
+	tokenType              string
+	totalSupply            *big.Int
+	feeAddress             string
+	feeTiersList           interface{}
+	assetGLBUri            string
+	paused                 bool
+	transferable           bool
+	freezeAuthorityRevoked bool
+	mintAuthorityRevoked   bool
+	updateAuthorityRevoked bool
+	allowedUsers           map[string]bool
+	blockedUsers           map[string]bool
+	frozenAccounts         map[string]bool
+
+	// Fungible balances by owner and non-fungible units by UUID, each with
+	// their keys in creation order.
+	balances     map[string]*balanceRecord
+	balanceOrder []string
+	nfts         map[string]*nftRecord
+	nftOrder     []string
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type balanceRecord struct {
+	owner     string
+	amount    *big.Int
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type nftRecord struct {
+	uuid      string
+	owner     string
+	burned    bool
+	burnedAt  *time.Time
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// tokenMetadataFields are the AddToken and UpdateMetadata fields the fake
+// node stores without interpreting them.
+var tokenMetadataFields = []string{
+	"symbol", "name", "decimals", "description", "image", "website",
+	"tags_social_media", "tags_category", "tags", "creator", "creator_website",
+	"expired_at", "asset_type",
+}
+
+type addTokenInput struct {
+	Owner                  string          `json:"owner"`
+	TotalSupply            string          `json:"total_supply"`
+	TokenType              string          `json:"token_type"`
+	FeeAddress             string          `json:"fee_address"`
+	FeeTiersList           interface{}     `json:"fee_tiers_list"`
+	AssetGLBUri            string          `json:"asset_glb_uri"`
+	Paused                 bool            `json:"paused"`
+	Transferable           bool            `json:"transferable"`
+	FreezeAuthorityRevoked bool            `json:"freeze_authority_revoked"`
+	MintAuthorityRevoked   bool            `json:"mint_authority_revoked"`
+	UpdateAuthorityRevoked bool            `json:"update_authority_revoked"`
+	AllowedUsers           map[string]bool `json:"allowed_users"`
+	BlockedUsers           map[string]bool `json:"blocked_users"`
+	FrozenAccounts         map[string]bool `json:"frozen_accounts"`
+}
+
+func (t *tokenRecord) object() object {
+	obj := object{}
+	for k, v := range t.metadata {
+		obj[k] = v
+	}
+	obj["address"] = t.address
+	obj["owner"] = t.owner
+	obj["token_type"] = t.tokenType
+	obj["total_supply"] = t.totalSupply.String()
+	obj["fee_address"] = t.feeAddress
+	obj["fee_tiers_list"] = t.feeTiersList
+	obj["asset_glb_uri"] = t.assetGLBUri
+	obj["paused"] = t.paused
+	obj["transferable"] = t.transferable
+	obj["freeze_authority_revoked"] = t.freezeAuthorityRevoked
+	obj["mint_authority_revoked"] = t.mintAuthorityRevoked
+	obj["update_authority_revoked"] = t.updateAuthorityRevoked
+	obj["allowed_users"] = t.allowedUsers
+	obj["blocked_users"] = t.blockedUsers
+	obj["frozen_accounts"] = t.frozenAccounts
+	obj["created_at"] = t.createdAt
+	obj["updated_at"] = t.updatedAt
+	return obj
+}
+
+func (t *tokenRecord) fungible() bool {
+	return t.tokenType != tokenV1Domain.NON_FUNGIBLE
+}
+
+func (t *tokenRecord) balanceObject(b *balanceRecord) object {
+	return object{
+		"token_address": t.address,
+		"owner_address": b.owner,
+		"amount":        b.amount.String(),
+		"token_type":    t.tokenType,
+		"created_at":    b.createdAt,
+		"updated_at":    b.updatedAt,
+	}
+}
+
+func (t *tokenRecord) nftObject(u *nftRecord) object {
+	return object{
+		"token_address": t.address,
+		"owner_address": u.owner,
+		"amount":        "1",
+		"token_type":    t.tokenType,
+		"token_uuid":    u.uuid,
+		"burned":        u.burned,
+		"burned_at":     u.burnedAt,
+		"created_at":    u.createdAt,
+		"updated_at":    u.updatedAt,
+	}
+}
+
+func (n *Node) sendToken(c call, out *output) error {
+	if c.method == tokenV1.METHOD_ADD_TOKEN {
+		return n.addToken(c, c.to, out)
+	}
+
+	t, ok := n.tokens[c.to]
+	if !ok {
+		return fmt.Errorf("token not found: %s", c.to)
+	}
+
+	switch c.method {
+	case tokenV1.METHOD_MINT_TOKEN:
+		var in struct {
+			MintTo string `json:"mint_to"`
+			Amount string `json:"amount"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		if err := t.requireOwner(c.from); err != nil {
+			return err
+		}
+		if t.mintAuthorityRevoked {
+			return fmt.Errorf("mint authority revoked for token %s", t.address)
+		}
+		return t.mint(in.MintTo, in.Amount, c.now, out)
+
+	case tokenV1.METHOD_BURN_TOKEN:
+		var in struct {
+			Amount string   `json:"amount"`
+			UUIDs  []string `json:"uuids"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		return t.burn(c.from, in.Amount, in.UUIDs, c.now, out)
+
+	case tokenV1.METHOD_TRANSFER_TOKEN:
+		var in struct {
+			TransferTo    string   `json:"transfer_to"`
+			Amount        string   `json:"amount"`
+			TokenUUIDList []string `json:"token_uuid_list"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		return t.transfer(c.from, in.TransferTo, in.Amount, in.TokenUUIDList, c.now, out)
+	}
+
+	if err := t.requireOwner(c.from); err != nil {
+		return err
+	}
+
+	switch c.method {
+	case tokenV1.METHOD_FREEZE_WALLET, tokenV1.METHOD_UNFREEZE_WALLET:
+		var in struct {
+			Wallet string `json:"wallet"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		if t.freezeAuthorityRevoked {
+			return fmt.Errorf("freeze authority revoked for token %s", t.address)
+		}
+		logType := tokenV1Domain.TOKEN_FREEZE_ACCOUNT_LOG
+		if c.method == tokenV1.METHOD_FREEZE_WALLET {
+			t.frozenAccounts[in.Wallet] = true
+		} else {
+			delete(t.frozenAccounts, in.Wallet)
+			logType = tokenV1Domain.TOKEN_UNFREEZE_ACCOUNT_LOG
+		}
+		out.log(t.address, logType, object{
+			"token_address":  t.address,
+			"frozen_account": in.Wallet,
+		})
+
+	case tokenV1.METHOD_ADD_ALLOWED_USERS, tokenV1.METHOD_REMOVE_ALLOWED_USERS:
+		var in struct {
+			AllowedUsers map[string]bool `json:"allowed_users"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		logType := tokenV1Domain.TOKEN_ALLOWED_USERS_ADDED_LOG
+		if c.method == tokenV1.METHOD_REMOVE_ALLOWED_USERS {
+			logType = tokenV1Domain.TOKEN_ALLOWED_USERS_REMOVED_LOG
+		}
+		updateUsers(t.allowedUsers, in.AllowedUsers, c.method == tokenV1.METHOD_ADD_ALLOWED_USERS)
+		out.log(t.address, logType, object{
+			"address":       t.address,
+			"allowed_users": in.AllowedUsers,
+		})
+
+	case tokenV1.METHOD_ADD_BLOCKED_USERS, tokenV1.METHOD_REMOVE_BLOCKED_USERS:
+		var in struct {
+			BlockedUsers map[string]bool `json:"blocked_users"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		logType := tokenV1Domain.TOKEN_BLOCKED_USERS_ADDED_LOG
+		if c.method == tokenV1.METHOD_REMOVE_BLOCKED_USERS {
+			logType = tokenV1Domain.TOKEN_BLOCKED_USERS_REMOVED_LOG
+		}
+		updateUsers(t.blockedUsers, in.BlockedUsers, c.method == tokenV1.METHOD_ADD_BLOCKED_USERS)
+		out.log(t.address, logType, object{
+			"address":       t.address,
+			"blocked_users": in.BlockedUsers,
+		})
+
+	case tokenV1.METHOD_REVOKE_FREEZE_AUTHORITY:
+		var in struct {
+			Revoked bool `json:"revoked"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t.freezeAuthorityRevoked = in.Revoked
+		out.log(t.address, tokenV1Domain.TOKEN_FREEZE_AUTHORITY_REVOKED_LOG, object{
+			"address":                  t.address,
+			"freeze_authority_revoked": in.Revoked,
+		})
+
+	case tokenV1.METHOD_REVOKE_MINT_AUTHORITY:
+		var in struct {
+			Revoked bool `json:"revoked"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t.mintAuthorityRevoked = in.Revoked
+		out.log(t.address, tokenV1Domain.TOKEN_MINT_AUTHORITY_REVOKED_LOG, object{
+			"address":                t.address,
+			"mint_authority_revoked": in.Revoked,
+		})
+
+	case tokenV1.METHOD_REVOKE_UPDATE_AUTHORITY:
+		var in struct {
+			Revoked bool `json:"revoked"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t.updateAuthorityRevoked = in.Revoked
+		out.log(t.address, tokenV1Domain.TOKEN_UPDATE_AUTHORITY_REVOKED_LOG, object{
+			"address":                  t.address,
+			"update_authority_revoked": in.Revoked,
+		})
+
+	case tokenV1.METHOD_UPDATE_METADATA:
+		if t.updateAuthorityRevoked {
+			return fmt.Errorf("update authority revoked for token %s", t.address)
+		}
+		var in object
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		event := object{"address": t.address}
+		for _, k := range tokenMetadataFields {
+			if v, ok := in[k]; ok {
+				t.metadata[k] = v
+				event[k] = v
+			}
+		}
+		out.log(t.address, tokenV1Domain.TOKEN_METADATA_UPDATED_LOG, event)
+
+	case tokenV1.METHOD_PAUSE_TOKEN, tokenV1.METHOD_UNPAUSE_TOKEN:
+		t.paused = c.method == tokenV1.METHOD_PAUSE_TOKEN
+		logType := tokenV1Domain.TOKEN_PAUSED_LOG
+		if !t.paused {
+			logType = tokenV1Domain.TOKEN_UNPAUSED_LOG
+		}
+		out.log(t.address, logType, object{
+			"token_address": t.address,
+			"enabled":       t.paused,
+		})
+
+	case tokenV1.METHOD_UPDATE_FEE_TIERS:
+		var in struct {
+			FeeTiersList interface{} `json:"fee_tiers_list"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t.feeTiersList = in.FeeTiersList
+		out.log(t.address, tokenV1Domain.TOKEN_FEE_UPDATED_LOG, object{
+			"token_address":  t.address,
+			"fee_tiers_list": in.FeeTiersList,
+		})
+
+	case tokenV1.METHOD_UPDATE_FEE_ADDRESS:
+		var in struct {
+			FeeAddress string `json:"fee_address"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t.feeAddress = in.FeeAddress
+		out.log(t.address, tokenV1Domain.TOKEN_FEE_ADDRESS_UPDATED_LOG, object{
+			"token_address": t.address,
+			"fee_address":   in.FeeAddress,
+		})
+
+	case tokenV1.METHOD_UPDATE_GLB_FILE:
+		var in struct {
+			NewAssetGLBUri string `json:"new_asset_glb_uri"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t.assetGLBUri = in.NewAssetGLBUri
+		out.log(t.address, tokenV1Domain.TOKEN_UPDATE_GLB_FILE_LOG, object{
+			"address":       t.address,
+			"asset_glb_uri": in.NewAssetGLBUri,
+		})
+
+	case tokenV1.METHOD_TRANSFERABLE_TOKEN, tokenV1.METHOD_UNTRANSFERABLE_TOKEN:
+		t.transferable = c.method == tokenV1.METHOD_TRANSFERABLE_TOKEN
+		logType := tokenV1Domain.TOKEN_TRANSFERABLE_LOG
+		if !t.transferable {
+			logType = tokenV1Domain.TOKEN_UNTRANSFERABLE_LOG
+		}
+		out.log(t.address, logType, object{
+			"token_address": t.address,
+			"transferable":  t.transferable,
+		})
+
+	default:
+		return fmt.Errorf("token method not supported by the fake node: %s", c.method)
+	}
+
+	t.updatedAt = c.now
+	return nil
+}
+
+func (n *Node) addToken(c call, address string, out *output) error {
+	var in addTokenInput
+	if err := c.decode(&in); err != nil {
+		return err
+	}
+	metadata := object{}
+	if err := c.decode(&metadata); err != nil {
+		return err
+	}
+
+	_, err := n.createToken(address, in, metadata, c.now, out)
+	return err
+}
+
+// createToken stores a new token at address and mints its initial supply to
+// the owner.
+func (n *Node) createToken(address string, in addTokenInput, metadata object, now time.Time, out *output) (*tokenRecord, error) {
+	if _, exists := n.tokens[address]; exists {
+		return nil, fmt.Errorf("token already exists: %s", address)
+	}
+	if in.Owner == "" {
+		return nil, fmt.Errorf("owner not set")
+	}
+	if in.TokenType != tokenV1Domain.FUNGIBLE && in.TokenType != tokenV1Domain.NON_FUNGIBLE {
+		return nil, fmt.Errorf("invalid token type: %s", in.TokenType)
+	}
+
+	totalSupply := new(big.Int)
+	if in.TotalSupply != "" {
+		var err error
+		if totalSupply, err = parseAmount(in.TotalSupply); err != nil {
+			return nil, err
+		}
+	}
+
+	t := &tokenRecord{
+		address:                address,
+		owner:                  in.Owner,
+		metadata:               object{},
+		tokenType:              in.TokenType,
+		totalSupply:            totalSupply,
+		feeAddress:             in.FeeAddress,
+		feeTiersList:           in.FeeTiersList,
+		assetGLBUri:            in.AssetGLBUri,
+		paused:                 in.Paused,
+		transferable:           in.Transferable,
+		freezeAuthorityRevoked: in.FreezeAuthorityRevoked,
+		mintAuthorityRevoked:   in.MintAuthorityRevoked,
+		updateAuthorityRevoked: in.UpdateAuthorityRevoked,
+		allowedUsers:           copyUsers(in.AllowedUsers),
+		blockedUsers:           copyUsers(in.BlockedUsers),
+		frozenAccounts:         copyUsers(in.FrozenAccounts),
+		balances:               make(map[string]*balanceRecord),
+		nfts:                   make(map[string]*nftRecord),
+		createdAt:              now,
+		updatedAt:              now,
+	}
+	for _, k := range tokenMetadataFields {
+		if v, ok := metadata[k]; ok {
+			t.metadata[k] = v
+		}
+	}
+
+	out.log(t.address, tokenV1Domain.TOKEN_CREATED_LOG, t.object())
+	if t.totalSupply.Sign() > 0 {
+		// The initial supply is already counted in total_supply.
+		if err := t.mintUnits(t.owner, t.totalSupply.String(), false, now, out); err != nil {
+			return nil, err
+		}
+	}
+
+	n.tokens[address] = t
+	n.tokenOrder = append(n.tokenOrder, address)
+	return t, nil
+}
+
+func (t *tokenRecord) requireOwner(from string) error {
+	if from != t.owner {
+		return fmt.Errorf("only the token owner can do this: %s", from)
+	}
+	return nil
+}
+
+// checkActive rejects moves of the token by or to account.
+func (t *tokenRecord) checkActive(accounts ...string) error {
+	if t.paused {
+		return fmt.Errorf("token %s is paused", t.address)
+	}
+	for _, account := range accounts {
+		if t.frozenAccounts[account] {
+			return fmt.Errorf("account %s is frozen", account)
+		}
+		if t.blockedUsers[account] {
+			return fmt.Errorf("account %s is blocked", account)
+		}
+	}
+	return nil
+}
+
+// mint creates amount new units for to and logs the mint, the supply change
+// and the balance change.
+func (t *tokenRecord) mint(to, amount string, now time.Time, out *output) error {
+	if err := t.checkActive(to); err != nil {
+		return err
+	}
+	return t.mintUnits(to, amount, true, now, out)
+}
+
+// mintUnits credits amount new units to to. The total supply is only raised
+// when increaseSupply is set.
+func (t *tokenRecord) mintUnits(to, amount string, increaseSupply bool, now time.Time, out *output) error {
+	value, err := parseAmount(amount)
+	if err != nil {
+		return err
+	}
+	if value.Sign() <= 0 {
+		return fmt.Errorf("amount must be greater than 0: %s", amount)
+	}
+
+	if t.fungible() {
+		out.log(t.address, tokenV1Domain.TOKEN_MINTED_FT_LOG, object{
+			"token_address": t.address,
+			"mint_to":       to,
+			"amount":        amount,
+			"token_type":    t.tokenType,
+		})
+		if increaseSupply {
+			t.addTotalSupply(value, out)
+		}
+		t.credit(to, value, now)
+		out.log(t.address, tokenV1Domain.TOKEN_BALANCE_INCREASED_FT_LOG, t.balanceDelta(to, value))
+	} else {
+		if !value.IsInt64() {
+			return fmt.Errorf("too many units to mint: %s", amount)
+		}
+		uuids := make([]string, 0, value.Int64())
+		for i := int64(0); i < value.Int64(); i++ {
+			id, err := uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("failed to generate token UUID: %w", err)
+			}
+			t.nfts[id.String()] = &nftRecord{
+				uuid:      id.String(),
+				owner:     to,
+				createdAt: now,
+				updatedAt: now,
+			}
+			t.nftOrder = append(t.nftOrder, id.String())
+			uuids = append(uuids, id.String())
+		}
+		out.log(t.address, tokenV1Domain.TOKEN_MINTED_NFT_LOG, object{
+			"token_address":   t.address,
+			"mint_to":         to,
+			"amount":          amount,
+			"token_type":      t.tokenType,
+			"token_uuid_list": uuids,
+		})
+		if increaseSupply {
+			t.addTotalSupply(value, out)
+		}
+		out.log(t.address, tokenV1Domain.TOKEN_BALANCE_INCREASED_NFT_LOG, t.nftDelta(to, uuids))
+	}
+
+	t.updatedAt = now
+	return nil
+}
+
+func (t *tokenRecord) burn(from, amount string, uuids []string, now time.Time, out *output) error {
+	if err := t.checkActive(from); err != nil {
+		return err
+	}
+
+	if t.fungible() {
+		value, err := parseAmount(amount)
+		if err != nil {
+			return err
+		}
+		if err := t.debit(from, value, now); err != nil {
+			return err
+		}
+		out.log(t.address, tokenV1Domain.TOKEN_BURNED_FT_LOG, object{
+			"token_address": t.address,
+			"burn_from":     from,
+			"amount":        amount,
+			"token_type":    t.tokenType,
+		})
+		t.subTotalSupply(value, out)
+		out.log(t.address, tokenV1Domain.TOKEN_BALANCE_DECREASED_FT_LOG, t.balanceDelta(from, value))
+	} else {
+		units, err := t.ownedUnits(from, uuids)
+		if err != nil {
+			return err
+		}
+		for _, u := range units {
+			burnedAt := now
+			u.burned = true
+			u.burnedAt = &burnedAt
+			u.updatedAt = now
+		}
+		out.log(t.address, tokenV1Domain.TOKEN_BURNED_NFT_LOG, object{
+			"token_address": t.address,
+			"burn_from":     from,
+			"amount":        fmt.Sprint(len(uuids)),
+			"token_type":    t.tokenType,
+			"tokens_uuid":   uuids,
+		})
+		t.subTotalSupply(big.NewInt(int64(len(uuids))), out)
+		out.log(t.address, tokenV1Domain.TOKEN_BALANCE_DECREASED_NFT_LOG, t.nftDelta(from, uuids))
+	}
+
+	t.updatedAt = now
+	return nil
+}
+
+func (t *tokenRecord) transfer(from, to, amount string, uuids []string, now time.Time, out *output) error {
+	if !t.transferable && from != t.owner {
+		return fmt.Errorf("token %s is not transferable", t.address)
+	}
+	if err := t.checkActive(from, to); err != nil {
+		return err
+	}
+
+	if t.fungible() {
+		value, err := parseAmount(amount)
+		if err != nil {
+			return err
+		}
+		if err := t.debit(from, value, now); err != nil {
+			return err
+		}
+		t.credit(to, value, now)
+		out.log(t.address, tokenV1Domain.TOKEN_TRANSFERRED_FT_LOG, object{
+			"token_address": t.address,
+			"from_address":  from,
+			"to_address":    to,
+			"amount":        amount,
+			"token_type":    t.tokenType,
+		})
+		out.log(t.address, tokenV1Domain.TOKEN_BALANCE_DECREASED_FT_LOG, t.balanceDelta(from, value))
+		out.log(t.address, tokenV1Domain.TOKEN_BALANCE_INCREASED_FT_LOG, t.balanceDelta(to, value))
+	} else {
+		units, err := t.ownedUnits(from, uuids)
+		if err != nil {
+			return err
+		}
+		for _, u := range units {
+			u.owner = to
+			u.updatedAt = now
+		}
+		out.log(t.address, tokenV1Domain.TOKEN_TRANSFERRED_NFT_LOG, object{
+			"token_address":   t.address,
+			"from_address":    from,
+			"to_address":      to,
+			"amount":          fmt.Sprint(len(uuids)),
+			"token_type":      t.tokenType,
+			"token_uuid_list": uuids,
+		})
+		out.log(t.address, tokenV1Domain.TOKEN_BALANCE_DECREASED_NFT_LOG, t.nftDelta(from, uuids))
+		out.log(t.address, tokenV1Domain.TOKEN_BALANCE_INCREASED_NFT_LOG, t.nftDelta(to, uuids))
+	}
+
+	t.updatedAt = now
+	return nil
+}
+
+func (t *tokenRecord) ownedUnits(owner string, uuids []string) ([]*nftRecord, error) {
+	if len(uuids) == 0 {
+		return nil, fmt.Errorf("token UUID list not set")
+	}
+
+	units := make([]*nftRecord, 0, len(uuids))
+	seen := make(map[string]bool, len(uuids))
+	for _, id := range uuids {
+		u, ok := t.nfts[id]
+		if !ok || u.burned || u.owner != owner || seen[id] {
+			return nil, fmt.Errorf("token %s is not owned by %s", id, owner)
+		}
+		seen[id] = true
+		units = append(units, u)
+	}
+	return units, nil
+}
+
+func (t *tokenRecord) credit(owner string, value *big.Int, now time.Time) {
+	b, ok := t.balances[owner]
+	if !ok {
+		b = &balanceRecord{owner: owner, amount: new(big.Int), createdAt: now}
+		t.balances[owner] = b
+		t.balanceOrder = append(t.balanceOrder, owner)
+	}
+	b.amount.Add(b.amount, value)
+	b.updatedAt = now
+}
+
+func (t *tokenRecord) debit(owner string, value *big.Int, now time.Time) error {
+	if value.Sign() <= 0 {
+		return fmt.Errorf("amount must be greater than 0: %s", value)
+	}
+	b, ok := t.balances[owner]
+	if !ok || b.amount.Cmp(value) < 0 {
+		return fmt.Errorf("insufficient balance for %s", owner)
+	}
+	b.amount.Sub(b.amount, value)
+	b.updatedAt = now
+	return nil
+}
+
+func (t *tokenRecord) addTotalSupply(value *big.Int, out *output) {
+	t.totalSupply.Add(t.totalSupply, value)
+	out.log(t.address, tokenV1Domain.TOKEN_TOTAL_SUPPLY_INCREASED_LOG, object{
+		"token_address": t.address,
+		"amount":        value.String(),
+	})
+}
+
+func (t *tokenRecord) subTotalSupply(value *big.Int, out *output) {
+	t.totalSupply.Sub(t.totalSupply, value)
+	out.log(t.address, tokenV1Domain.TOKEN_TOTAL_SUPPLY_DECREASED_LOG, object{
+		"token_address": t.address,
+		"amount":        value.String(),
+	})
+}
+
+func (t *tokenRecord) balanceDelta(owner string, value *big.Int) object {
+	return object{
+		"token_address": t.address,
+		"owner_address": owner,
+		"amount":        value.String(),
+		"token_type":    t.tokenType,
+	}
+}
+
+func (t *tokenRecord) nftDelta(owner string, uuids []string) object {
+	return object{
+		"token_address":   t.address,
+		"owner_address":   owner,
+		"token_type":      t.tokenType,
+		"token_uuid_list": uuids,
+	}
+}
+
+func (n *Node) getTokenState(c call, out *output) error {
+	switch c.method {
+	case tokenV1.METHOD_GET_TOKEN:
+		var in struct {
+			Symbol string `json:"symbol"`
+			Name   string `json:"name"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t, ok := n.tokens[c.to]
+		if !ok {
+			for _, address := range n.tokenAddresses() {
+				candidate := n.tokens[address]
+				if (in.Symbol != "" && candidate.metadata["symbol"] == in.Symbol) ||
+					(in.Name != "" && candidate.metadata["name"] == in.Name) {
+					t, ok = candidate, true
+					break
+				}
+			}
+		}
+		if !ok {
+			return fmt.Errorf("token not found: %s", c.to)
+		}
+		out.state(t.object())
+		return nil
+
+	case tokenV1.METHOD_LIST_TOKENS:
+		var in struct {
+			Owner     string `json:"owner"`
+			Symbol    string `json:"symbol"`
+			Name      string `json:"name"`
+			TokenType string `json:"token_type"`
+			Page      int    `json:"page"`
+			Limit     int    `json:"limit"`
+			Ascending bool   `json:"ascending"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		tokens := make([]object, 0)
+		for _, address := range n.tokenAddresses() {
+			t := n.tokens[address]
+			if (in.Owner != "" && t.owner != in.Owner) ||
+				(in.Symbol != "" && t.metadata["symbol"] != in.Symbol) ||
+				(in.Name != "" && t.metadata["name"] != in.Name) ||
+				(in.TokenType != "" && t.tokenType != in.TokenType) {
+				continue
+			}
+			tokens = append(tokens, t.object())
+		}
+		out.state(paginate(tokens, in.Page, in.Limit, in.Ascending))
+		return nil
+
+	case tokenV1.METHOD_GET_TOKEN_BALANCE:
+		var in struct {
+			OwnerAddress string `json:"owner_address"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t, ok := n.tokens[c.to]
+		if !ok {
+			return fmt.Errorf("token not found: %s", c.to)
+		}
+		if !t.fungible() {
+			units := t.units(in.OwnerAddress)
+			if len(units) == 0 {
+				return fmt.Errorf("balance not found for %s", in.OwnerAddress)
+			}
+			b := &balanceRecord{
+				owner:     in.OwnerAddress,
+				amount:    big.NewInt(int64(len(units))),
+				createdAt: units[0].createdAt,
+				updatedAt: units[len(units)-1].updatedAt,
+			}
+			out.state(t.balanceObject(b))
+			return nil
+		}
+		b, ok := t.balances[in.OwnerAddress]
+		if !ok {
+			return fmt.Errorf("balance not found for %s", in.OwnerAddress)
+		}
+		out.state(t.balanceObject(b))
+		return nil
+
+	case tokenV1.METHOD_GET_TOKEN_BALANCE_NFT:
+		var in struct {
+			OwnerAddress string `json:"owner_address"`
+			TokenUUID    string `json:"token_uuid"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		t, ok := n.tokens[c.to]
+		if !ok {
+			return fmt.Errorf("token not found: %s", c.to)
+		}
+		u, ok := t.nfts[in.TokenUUID]
+		if !ok || u.owner != in.OwnerAddress {
+			return fmt.Errorf("balance not found for %s", in.TokenUUID)
+		}
+		out.state(t.nftObject(u))
+		return nil
+
+	case tokenV1.METHOD_LIST_TOKEN_BALANCES:
+		var in struct {
+			Address      string `json:"address"`
+			OwnerAddress string `json:"owner_address"`
+			TokenType    string `json:"token_type"`
+			Page         int    `json:"page"`
+			Limit        int    `json:"limit"`
+			Ascending    bool   `json:"ascending"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		balances := make([]object, 0)
+		for _, address := range n.tokenAddresses() {
+			t := n.tokens[address]
+			if (in.Address != "" && t.address != in.Address) ||
+				(in.TokenType != "" && t.tokenType != in.TokenType) {
+				continue
+			}
+			for _, owner := range t.balanceOrder {
+				if in.OwnerAddress == "" || owner == in.OwnerAddress {
+					balances = append(balances, t.balanceObject(t.balances[owner]))
+				}
+			}
+			for _, id := range t.nftOrder {
+				u := t.nfts[id]
+				if !u.burned && (in.OwnerAddress == "" || u.owner == in.OwnerAddress) {
+					balances = append(balances, t.nftObject(u))
+				}
+			}
+		}
+		out.state(paginate(balances, in.Page, in.Limit, in.Ascending))
+		return nil
+	}
+
+	return fmt.Errorf("token method not supported by the fake node: %s", c.method)
+}
+
+// units returns the unburned units owned by owner, oldest first.
+func (t *tokenRecord) units(owner string) []*nftRecord {
+	var units []*nftRecord
+	for _, id := range t.nftOrder {
+		if u := t.nfts[id]; !u.burned && u.owner == owner {
+			units = append(units, u)
+		}
+	}
+	return units
+}
+
+// tokenAddresses returns the token addresses in creation order.
+func (n *Node) tokenAddresses() []string {
+	return n.tokenOrder
+}
+
+func parseAmount(amount string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount: %q", amount)
+	}
+	return value, nil
+}
+
+func copyUsers(users map[string]bool) map[string]bool {
+	cp := make(map[string]bool, len(users))
+	for k, v := range users {
+		if v {
+			cp[k] = true
+		}
+	}
+	return cp
+}
+
+func updateUsers(users, changes map[string]bool, add bool) {
+	for k, v := range changes {
+		if !v {
+			continue
+		}
+		if add {
+			users[k] = true
+		} else {
+			delete(users, k)
+		}
+	}
+}
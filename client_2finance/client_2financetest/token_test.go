@@ -0,0 +1,146 @@
+package client_2financetest_test
+
+import (
+	"testing"
+
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/2Finance-Labs/go-client-2finance/client_2finance/client_2financetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tokenV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
+)
+
+func TestNode_TokenNFTFlow(t *testing.T) {
+	owner := newKeySigner(t)
+	holder := newKeySigner(t)
+
+	node := client_2financetest.NewNode()
+	c := newClient(t, node, owner)
+	asHolder := newClient(t, node, holder)
+
+	tok := addToken(t, c, owner.publicKey, tokenV1Domain.NON_FUNGIBLE, "0")
+
+	mint, err := client2f.DecodeMintNFT(c.MintToken(tok.Address, owner.publicKey, "3"))
+	if err != nil {
+		t.Fatalf("MintToken: %v", err)
+	}
+	require.Len(t, mint.TokenUUIDList, 3, "minted units")
+
+	transfer, err := client2f.DecodeTransferNFT(c.TransferToken(tok.Address, holder.publicKey, "1", mint.TokenUUIDList[:1]))
+	if err != nil {
+		t.Fatalf("TransferToken: %v", err)
+	}
+	assert.Equal(t, mint.TokenUUIDList[:1], transfer.TokenUUIDList)
+
+	unit, err := client2f.DecodeTokenBalance(c.GetTokenBalanceNFT(tok.Address, holder.publicKey, mint.TokenUUIDList[0]))
+	if err != nil {
+		t.Fatalf("GetTokenBalanceNFT: %v", err)
+	}
+	assert.Equal(t, holder.publicKey, unit.OwnerAddress)
+	assert.Equal(t, mint.TokenUUIDList[0], unit.TokenUUID)
+
+	// A unit can only be moved by its owner.
+	_, err = c.TransferToken(tok.Address, newKeySigner(t).publicKey, "1", mint.TokenUUIDList[:1])
+	var contractErr *client2f.ContractError
+	require.ErrorAs(t, err, &contractErr)
+
+	burn, err := client2f.DecodeBurnNFT(asHolder.BurnToken(tok.Address, "1", mint.TokenUUIDList[:1]))
+	if err != nil {
+		t.Fatalf("BurnToken: %v", err)
+	}
+	assert.Equal(t, mint.TokenUUIDList[:1], burn.TokensUUID)
+
+	_, err = asHolder.TransferToken(tok.Address, owner.publicKey, "1", mint.TokenUUIDList[:1])
+	require.ErrorAs(t, err, &contractErr, "burned units cannot move")
+
+	ownerBalance, err := client2f.DecodeTokenBalance(c.GetTokenBalance(tok.Address, owner.publicKey))
+	if err != nil {
+		t.Fatalf("GetTokenBalance: %v", err)
+	}
+	assert.Equal(t, "2", ownerBalance.Amount)
+
+	state, err := client2f.DecodeTokenState(c.GetToken(tok.Address, "", ""))
+	if err != nil {
+		t.Fatalf("GetToken: %v", err)
+	}
+	assert.Equal(t, "2", state.TotalSupply)
+}
+
+func TestNode_TokenFreezeWallet(t *testing.T) {
+	owner := newKeySigner(t)
+	holder := newKeySigner(t)
+
+	node := client_2financetest.NewNode()
+	c := newClient(t, node, owner)
+	asHolder := newClient(t, node, holder)
+
+	tok := addToken(t, c, owner.publicKey, tokenV1Domain.FUNGIBLE, "1000")
+	_, err := c.TransferToken(tok.Address, holder.publicKey, "100", nil)
+	require.NoError(t, err)
+
+	// Only the owner can freeze.
+	_, err = asHolder.FreezeWallet(tok.Address, owner.publicKey)
+	var contractErr *client2f.ContractError
+	require.ErrorAs(t, err, &contractErr)
+
+	_, err = c.FreezeWallet(tok.Address, holder.publicKey)
+	require.NoError(t, err)
+
+	state, err := client2f.DecodeTokenState(c.GetToken(tok.Address, "", ""))
+	require.NoError(t, err)
+	assert.True(t, state.FrozenAccounts[holder.publicKey])
+
+	// A frozen account can neither send nor receive.
+	_, err = asHolder.TransferToken(tok.Address, owner.publicKey, "1", nil)
+	require.ErrorAs(t, err, &contractErr)
+	_, err = c.TransferToken(tok.Address, holder.publicKey, "1", nil)
+	require.ErrorAs(t, err, &contractErr)
+
+	_, err = c.UnfreezeWallet(tok.Address, holder.publicKey)
+	require.NoError(t, err)
+
+	_, err = asHolder.TransferToken(tok.Address, owner.publicKey, "1", nil)
+	require.NoError(t, err)
+
+	// Once the freeze authority is revoked, nobody can freeze.
+	_, err = c.RevokeFreezeAuthority(tok.Address, true)
+	require.NoError(t, err)
+	_, err = c.FreezeWallet(tok.Address, holder.publicKey)
+	require.ErrorAs(t, err, &contractErr)
+}
+
+func TestNode_TokenPause(t *testing.T) {
+	owner := newKeySigner(t)
+	holder := newKeySigner(t)
+
+	node := client_2financetest.NewNode()
+	c := newClient(t, node, owner)
+
+	tok := addToken(t, c, owner.publicKey, tokenV1Domain.FUNGIBLE, "1000")
+
+	_, err := c.PauseToken(tok.Address, true)
+	require.NoError(t, err)
+
+	state, err := client2f.DecodeTokenState(c.GetToken(tok.Address, "", ""))
+	require.NoError(t, err)
+	assert.True(t, state.Paused)
+
+	// Nothing moves while the token is paused.
+	var contractErr *client2f.ContractError
+	_, err = c.TransferToken(tok.Address, holder.publicKey, "1", nil)
+	require.ErrorAs(t, err, &contractErr)
+	_, err = c.MintToken(tok.Address, owner.publicKey, "1")
+	require.ErrorAs(t, err, &contractErr)
+	_, err = c.BurnToken(tok.Address, "1", nil)
+	require.ErrorAs(t, err, &contractErr)
+
+	_, err = c.UnpauseToken(tok.Address, false)
+	require.NoError(t, err)
+
+	_, err = c.TransferToken(tok.Address, holder.publicKey, "1", nil)
+	require.NoError(t, err)
+
+	state, err = client2f.DecodeTokenState(c.GetToken(tok.Address, "", ""))
+	require.NoError(t, err)
+	assert.False(t, state.Paused)
+}
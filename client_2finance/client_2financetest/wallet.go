@@ -0,0 +1,90 @@
+package client_2financetest
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/contract/walletV1"
+	walletV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/walletV1/domain"
+)
+
+type walletRecord struct {
+	address   string
+	publicKey string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (w *walletRecord) object() object {
+	return object{
+		"address":    w.address,
+		"public_key": w.publicKey,
+		"created_at": w.createdAt,
+		"updated_at": w.updatedAt,
+	}
+}
+
+func (n *Node) sendWallet(c call, out *output) error {
+	switch c.method {
+	case walletV1.METHOD_ADD_WALLET:
+		var in struct {
+			Address   string `json:"address"`
+			PublicKey string `json:"public_key"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		if in.Address != c.to {
+			return fmt.Errorf("wallet address %s does not match contract %s", in.Address, c.to)
+		}
+		if _, exists := n.wallets[c.to]; exists {
+			return fmt.Errorf("wallet already exists: %s", c.to)
+		}
+		for _, w := range n.wallets {
+			if w.publicKey == in.PublicKey {
+				return fmt.Errorf("wallet already exists for public key: %s", in.PublicKey)
+			}
+		}
+
+		w := &walletRecord{
+			address:   c.to,
+			publicKey: in.PublicKey,
+			createdAt: c.now,
+			updatedAt: c.now,
+		}
+		n.wallets[c.to] = w
+		out.log(c.to, walletV1Domain.WALLET_CREATED_LOG, w.object())
+		return nil
+	}
+
+	return fmt.Errorf("wallet method not supported by the fake node: %s", c.method)
+}
+
+func (n *Node) getWalletState(c call, out *output) error {
+	switch c.method {
+	case walletV1.METHOD_GET_WALLET_BY_ADDRESS:
+		w, ok := n.wallets[c.to]
+		if !ok {
+			return fmt.Errorf("wallet not found: %s", c.to)
+		}
+		out.state(w.object())
+		return nil
+
+	case walletV1.METHOD_GET_WALLET_BY_PUBLIC_KEY:
+		var in struct {
+			PublicKey string `json:"public_key"`
+		}
+		if err := c.decode(&in); err != nil {
+			return err
+		}
+		for _, w := range n.wallets {
+			if w.publicKey == in.PublicKey {
+				out.state(w.object())
+				return nil
+			}
+		}
+		return fmt.Errorf("wallet not found for public key: %s", in.PublicKey)
+	}
+
+	return fmt.Errorf("wallet method not supported by the fake node: %s", c.method)
+}
@@ -0,0 +1,274 @@
+package client_2finance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/block"
+	blockchainLog "gitlab.com/2finance/2finance-network/blockchain/log"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultWaitTimeout  = 2 * time.Minute
+	defaultLookback     = 1000
+
+	// waitPageSize is the page size used to scan blocks and logs.
+	waitPageSize = 50
+)
+
+// Confirmation is a transaction found on chain by WaitForTransaction,
+// together with the block that contains it and the logs it emitted.
+type Confirmation struct {
+	Block       block.Block
+	Transaction transaction.Transaction
+	Logs        []blockchainLog.Log
+
+	// Confirmations is the number of blocks from the containing block to the
+	// latest one, both included. It is 1 while the containing block is the
+	// latest block.
+	Confirmations uint64
+}
+
+// WaitOption configures WaitForTransaction.
+type WaitOption func(*waitOptions) error
+
+type waitOptions struct {
+	confirmations uint64
+	pollInterval  time.Duration
+	timeout       time.Duration
+	lookback      uint64
+}
+
+// WithConfirmations sets how many blocks, the containing one included, must
+// be on chain before WaitForTransaction returns. The default is 1.
+func WithConfirmations(confirmations uint64) WaitOption {
+	return func(o *waitOptions) error {
+		if confirmations == 0 {
+			return validationErrorf("confirmations must be at least 1")
+		}
+		o.confirmations = confirmations
+		return nil
+	}
+}
+
+// WithPollInterval sets how often WaitForTransaction asks the node for new
+// blocks. The default is one second.
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(o *waitOptions) error {
+		if interval <= 0 {
+			return validationErrorf("poll interval must be greater than 0: %s", interval)
+		}
+		o.pollInterval = interval
+		return nil
+	}
+}
+
+// WithLookback sets how many blocks, counting back from the latest one when
+// the wait starts, WaitForTransaction scans for the transaction's block. A
+// transaction in an older block is not found and the wait times out. The
+// default is 1000.
+func WithLookback(blocks uint64) WaitOption {
+	return func(o *waitOptions) error {
+		if blocks == 0 {
+			return validationErrorf("lookback must be at least 1 block")
+		}
+		o.lookback = blocks
+		return nil
+	}
+}
+
+// WithWaitTimeout sets how long WaitForTransaction waits when the context has
+// no earlier deadline. The default is two minutes.
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(o *waitOptions) error {
+		if timeout <= 0 {
+			return validationErrorf("wait timeout must be greater than 0: %s", timeout)
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WaitForTransaction polls the node until the transaction with hash is in a
+// block with the requested number of confirmations, and returns that block,
+// the transaction and its logs. It fails with ErrTimeout when the wait
+// timeout or the context deadline passes first.
+func (c *networkClient) WaitForTransaction(ctx context.Context, hash string, opts ...WaitOption) (Confirmation, error) {
+	if hash == "" {
+		return Confirmation{}, validationErrorf("transaction hash is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	o := waitOptions{
+		confirmations: 1,
+		pollInterval:  defaultPollInterval,
+		timeout:       defaultWaitTimeout,
+		lookback:      defaultLookback,
+	}
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return Confirmation{}, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	w := &transactionWaiter{client: c.withContext(ctx), hash: hash, lookback: o.lookback}
+	for {
+		confirmation, done, err := w.poll(o.confirmations)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return Confirmation{}, fmt.Errorf("transaction %s not confirmed: %w", hash, contextError(ctxErr))
+			}
+			return Confirmation{}, err
+		}
+		if done {
+			return confirmation, nil
+		}
+
+		timer := time.NewTimer(o.pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return Confirmation{}, fmt.Errorf("transaction %s not confirmed: %w", hash, contextError(ctx.Err()))
+		case <-timer.C:
+		}
+	}
+}
+
+// transactionWaiter keeps what WaitForTransaction learned between polls so
+// each poll only looks at blocks it has not seen yet.
+type transactionWaiter struct {
+	client   *networkClient
+	hash     string
+	lookback uint64
+
+	tx        *transaction.Transaction
+	block     *block.Block
+	scanned   bool
+	scannedTo uint64
+	floor     uint64
+}
+
+// poll makes one round of requests and reports whether the transaction has
+// enough confirmations.
+func (w *transactionWaiter) poll(confirmations uint64) (Confirmation, bool, error) {
+	if w.tx == nil {
+		transactions, err := w.client.ListTransactions("", "", w.hash, nil, 0, 1, 1, true)
+		if err != nil {
+			return Confirmation{}, false, err
+		}
+		for i := range transactions {
+			if transactions[i].Hash == w.hash {
+				w.tx = &transactions[i]
+			}
+		}
+		if w.tx == nil {
+			return Confirmation{}, false, nil
+		}
+	}
+
+	latest, err := w.findBlock()
+	if err != nil || w.block == nil {
+		return Confirmation{}, false, err
+	}
+
+	if latest < w.block.Number {
+		latest = w.block.Number
+	}
+	depth := latest - w.block.Number + 1
+	if depth < confirmations {
+		return Confirmation{}, false, nil
+	}
+
+	logs, err := w.logs()
+	if err != nil {
+		return Confirmation{}, false, err
+	}
+
+	return Confirmation{
+		Block:         *w.block,
+		Transaction:   *w.tx,
+		Logs:          logs,
+		Confirmations: depth,
+	}, true, nil
+}
+
+// findBlock scans blocks from the latest one down to the last block scanned
+// by a previous poll, looking for the block that contains the transaction.
+// The first scan goes back no further than the lookback. It returns the
+// latest block number.
+func (w *transactionWaiter) findBlock() (uint64, error) {
+	var latest uint64
+scan:
+	for page := 1; ; page++ {
+		blocks, err := w.client.ListBlocks(0, time.Time{}, "", "", "", page, waitPageSize, false)
+		if err != nil {
+			return 0, err
+		}
+		if len(blocks) == 0 {
+			break
+		}
+		if page == 1 {
+			latest = blocks[0].Number
+			if w.block != nil {
+				// Already found; only the chain height is needed.
+				return latest, nil
+			}
+			if !w.scanned && latest >= w.lookback {
+				w.floor = latest - w.lookback + 1
+			}
+		}
+
+		for i := range blocks {
+			if blocks[i].Number < w.floor {
+				break scan
+			}
+			if w.scanned && blocks[i].Number <= w.scannedTo {
+				w.scannedTo = latest
+				return latest, nil
+			}
+			if blockContains(blocks[i], w.hash) {
+				w.block = &blocks[i]
+				return latest, nil
+			}
+		}
+
+		if len(blocks) < waitPageSize {
+			break
+		}
+	}
+
+	w.scanned = true
+	w.scannedTo = latest
+	return latest, nil
+}
+
+func (w *transactionWaiter) logs() ([]blockchainLog.Log, error) {
+	var logs []blockchainLog.Log
+	for page := 1; ; page++ {
+		batch, err := w.client.ListLogs(nil, 0, w.hash, nil, "", page, waitPageSize, true)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, batch...)
+		if len(batch) < waitPageSize {
+			return logs, nil
+		}
+	}
+}
+
+func blockContains(b block.Block, hash string) bool {
+	for _, tx := range b.Transactions {
+		if tx.Hash == hash {
+			return true
+		}
+	}
+	return false
+}
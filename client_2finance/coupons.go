@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"gitlab.com/2finance/2finance-network/blockchain/contract/couponV1"
+	couponV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/couponV1/domain"
+	couponV1Models "gitlab.com/2finance/2finance-network/blockchain/contract/couponV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/types"
 	"gitlab.com/2finance/2finance-network/blockchain/utils"
@@ -43,65 +45,65 @@ func (c *networkClient) AddCoupon(
 ) (types.ContractOutput, error) {
 
 	// Sender validations
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid coupon address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid coupon address: %w", err)
 	}
 	if !(discountType == "percentage" || discountType == "fixed-amount") {
-		return types.ContractOutput{}, fmt.Errorf("invalid discount_type: %s", discountType)
+		return types.ContractOutput{}, validationErrorf("invalid discount_type: %s", discountType)
 	}
 	// Basic param sanity (business rules enforced again in contract/domain)
 	if discountType == "percentage" && percentageBPS == "" {
-		return types.ContractOutput{}, fmt.Errorf("percentage_bps must be set for discount_type=percentage")
+		return types.ContractOutput{}, validationErrorf("percentage_bps must be set for discount_type=percentage")
 	}
 	if discountType == "fixed-amount" && fixedAmount == "" {
-		return types.ContractOutput{}, fmt.Errorf("fixed_amount must be set for discount_type=fixed-amount")
+		return types.ContractOutput{}, validationErrorf("fixed_amount must be set for discount_type=fixed-amount")
 	}
 	if voucherOwner == "" {
-		return types.ContractOutput{}, fmt.Errorf("voucherOwner must be set")
+		return types.ContractOutput{}, validationErrorf("voucherOwner must be set")
 	}
 	if symbol == "" {
-		return types.ContractOutput{}, fmt.Errorf("symbol must be set")
+		return types.ContractOutput{}, validationErrorf("symbol must be set")
 	}
 	if name == "" {
-		return types.ContractOutput{}, fmt.Errorf("name must be set")
+		return types.ContractOutput{}, validationErrorf("name must be set")
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount must be set")
+		return types.ContractOutput{}, validationErrorf("amount must be set")
 	}
 	if description == "" {
-		return types.ContractOutput{}, fmt.Errorf("description must be set")
+		return types.ContractOutput{}, validationErrorf("description must be set")
 	}
 	if image == "" {
-		return types.ContractOutput{}, fmt.Errorf("image must be set")
+		return types.ContractOutput{}, validationErrorf("image must be set")
 	}
 	if website == "" {
-		return types.ContractOutput{}, fmt.Errorf("website must be set")
+		return types.ContractOutput{}, validationErrorf("website must be set")
 	}
 	if tagsSocialMedia == nil {
-		return types.ContractOutput{}, fmt.Errorf("tagsSocialMedia must be set")
+		return types.ContractOutput{}, validationErrorf("tagsSocialMedia must be set")
 	}
 	if tagsCategory == nil {
-		return types.ContractOutput{}, fmt.Errorf("tagsCategory must be set")
+		return types.ContractOutput{}, validationErrorf("tagsCategory must be set")
 	}
 	if tags == nil {
-		return types.ContractOutput{}, fmt.Errorf("tags must be set")
+		return types.ContractOutput{}, validationErrorf("tags must be set")
 	}
 	if creator == "" {
-		return types.ContractOutput{}, fmt.Errorf("creator must be set")
+		return types.ContractOutput{}, validationErrorf("creator must be set")
 	}
 	if creatorWebsite == "" {
-		return types.ContractOutput{}, fmt.Errorf("creatorWebsite must be set")
+		return types.ContractOutput{}, validationErrorf("creatorWebsite must be set")
 	}
 	if assetGLBUri == "" {
-		return types.ContractOutput{}, fmt.Errorf("assetGLBUri must be set")
+		return types.ContractOutput{}, validationErrorf("assetGLBUri must be set")
 	}
 	// Deploy new coupon program
 	to := address
@@ -139,7 +141,7 @@ func (c *networkClient) AddCoupon(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 func (c *networkClient) UpdateCoupon(
@@ -158,24 +160,24 @@ func (c *networkClient) UpdateCoupon(
 ) (types.ContractOutput, error) {
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid coupon address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid coupon address: %w", err)
 	}
 	if tokenAddress != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 		}
 	}
 	if discountType != "" && !(discountType == "percentage" || discountType == "fixed-amount") {
-		return types.ContractOutput{}, fmt.Errorf("invalid discount_type: %s", discountType)
+		return types.ContractOutput{}, validationErrorf("invalid discount_type: %s", discountType)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -201,24 +203,24 @@ func (c *networkClient) UpdateCoupon(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 func (c *networkClient) PauseCoupon(address string, pause bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid coupon address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid coupon address: %w", err)
 	}
 	if !pause {
-		return types.ContractOutput{}, fmt.Errorf("pause must be true: paused=%t", pause)
+		return types.ContractOutput{}, validationErrorf("pause must be true: paused=%t", pause)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -234,24 +236,24 @@ func (c *networkClient) PauseCoupon(address string, pause bool) (types.ContractO
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 func (c *networkClient) UnpauseCoupon(address string, pause bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid coupon address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid coupon address: %w", err)
 	}
 	if pause {
-		return types.ContractOutput{}, fmt.Errorf("pause must be false: paused=%t", pause)
+		return types.ContractOutput{}, validationErrorf("pause must be false: paused=%t", pause)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -267,7 +269,7 @@ func (c *networkClient) UnpauseCoupon(address string, pause bool) (types.Contrac
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 func (c *networkClient) IssueVoucher(
@@ -276,25 +278,25 @@ func (c *networkClient) IssueVoucher(
 	amount string, // integer string in token base units
 ) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid coupon address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid coupon address: %w", err)
 	}
 	if toAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("to_address not set")
+		return types.ContractOutput{}, validationErrorf("to_address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(toAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid to_address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid to_address: %w", err)
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -311,7 +313,7 @@ func (c *networkClient) IssueVoucher(
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // Redeem a coupon for an order amount using a passcode preimage.
@@ -325,25 +327,25 @@ func (c *networkClient) RedeemVoucher(
 ) (types.ContractOutput, error) {
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid voucher address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid voucher address: %w", err)
 	}
 	if orderAmount == "" {
-		return types.ContractOutput{}, fmt.Errorf("order_amount not set")
+		return types.ContractOutput{}, validationErrorf("order_amount not set")
 	}
 	if passcode == "" {
-		return types.ContractOutput{}, fmt.Errorf("passcode (preimage) not set")
+		return types.ContractOutput{}, validationErrorf("passcode (preimage) not set")
 	}
 	if voucherUUID == "" {
-		return types.ContractOutput{}, fmt.Errorf("voucher_uuid must be set for non-fungible tokens")
+		return types.ContractOutput{}, validationErrorf("voucher_uuid must be set for non-fungible tokens")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -361,7 +363,7 @@ func (c *networkClient) RedeemVoucher(
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // ---------------------------------------------
@@ -369,16 +371,16 @@ func (c *networkClient) RedeemVoucher(
 // ---------------------------------------------
 
 func (c *networkClient) GetCoupon(address string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("coupon address must be set")
+		return types.ContractOutput{}, validationErrorf("coupon address must be set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid coupon address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid coupon address: %w", err)
 	}
 
 	method := couponV1.METHOD_GET_COUPON
@@ -396,29 +398,29 @@ func (c *networkClient) ListCoupons(
 	ascending bool,
 ) (types.ContractOutput, error) {
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if owner != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(owner); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 		}
 	}
 	if tokenAddress != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 		}
 	}
 	if programType != "" && !(programType == "percentage" || programType == "fixed-amount") {
-		return types.ContractOutput{}, fmt.Errorf("invalid program_type: %s", programType)
+		return types.ContractOutput{}, validationErrorf("invalid program_type: %s", programType)
 	}
 	if page < 1 {
-		return types.ContractOutput{}, fmt.Errorf("page must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("page must be greater than 0")
 	}
 	if limit < 1 {
-		return types.ContractOutput{}, fmt.Errorf("limit must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("limit must be greater than 0")
 	}
 
 	method := couponV1.METHOD_LIST_COUPONS
@@ -436,3 +438,28 @@ func (c *networkClient) ListCoupons(
 
 	return c.GetState("", method, data)
 }
+
+// DecodeCoupon returns the coupon logged by AddCoupon or UpdateCoupon.
+func DecodeCoupon(out types.ContractOutput, err error) (couponV1Domain.Coupon, error) {
+	return decodeEventResult[couponV1Domain.Coupon](out, err, "")
+}
+
+// DecodeIssuedVoucher returns the voucher issued by IssueVoucher.
+func DecodeIssuedVoucher(out types.ContractOutput, err error) (couponV1Domain.IssueVoucher, error) {
+	return decodeEventResult[couponV1Domain.IssueVoucher](out, err, couponV1Domain.VOUCHER_ISSUED_LOG)
+}
+
+// DecodeRedeemedVoucher returns the redemption performed by RedeemVoucher.
+func DecodeRedeemedVoucher(out types.ContractOutput, err error) (couponV1Domain.RedeemVoucher, error) {
+	return decodeEventResult[couponV1Domain.RedeemVoucher](out, err, couponV1Domain.VOUCHER_REDEEMED_LOG)
+}
+
+// DecodeCouponState returns the coupon read by GetCoupon.
+func DecodeCouponState(out types.ContractOutput, err error) (couponV1Models.CouponStateModel, error) {
+	return decodeStateResult[couponV1Models.CouponStateModel](out, err)
+}
+
+// DecodeCoupons returns the coupons read by ListCoupons.
+func DecodeCoupons(out types.ContractOutput, err error) ([]couponV1Models.CouponStateModel, error) {
+	return decodeStateResult[[]couponV1Models.CouponStateModel](out, err)
+}
@@ -0,0 +1,102 @@
+package client_2finance
+
+import (
+	"fmt"
+
+	blockchainLog "gitlab.com/2finance/2finance-network/blockchain/log"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+	"gitlab.com/2finance/2finance-network/blockchain/utils"
+)
+
+// DecodeEvent decodes the event of the first log in out whose type is
+// logType. An empty logType matches the first log.
+func DecodeEvent[T any](out types.ContractOutput, logType string) (T, error) {
+	var zero T
+
+	for i := range out.Logs {
+		lg, err := utils.UnmarshalLog[blockchainLog.Log](out.Logs[i])
+		if err != nil {
+			return zero, fmt.Errorf("failed to unmarshal log %d: %w", i, err)
+		}
+
+		if logType != "" && lg.LogType != logType {
+			continue
+		}
+
+		event, err := utils.UnmarshalEvent[T](lg.Event)
+		if err != nil {
+			return zero, fmt.Errorf("failed to unmarshal event of log %d (%s): %w", i, lg.LogType, err)
+		}
+
+		return event, nil
+	}
+
+	if logType == "" {
+		return zero, fmt.Errorf("contract output has no logs")
+	}
+
+	return zero, fmt.Errorf("contract output has no %q log", logType)
+}
+
+// DecodeEvents decodes the events of every log in out whose type is logType.
+// An empty logType matches every log.
+func DecodeEvents[T any](out types.ContractOutput, logType string) ([]T, error) {
+	events := make([]T, 0, len(out.Logs))
+
+	for i := range out.Logs {
+		lg, err := utils.UnmarshalLog[blockchainLog.Log](out.Logs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal log %d: %w", i, err)
+		}
+
+		if logType != "" && lg.LogType != logType {
+			continue
+		}
+
+		event, err := utils.UnmarshalEvent[T](lg.Event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event of log %d (%s): %w", i, lg.LogType, err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// DecodeState decodes the object of the first state in out.
+func DecodeState[T any](out types.ContractOutput) (T, error) {
+	var state T
+
+	if len(out.States) == 0 {
+		return state, fmt.Errorf("contract output has no states")
+	}
+
+	if err := utils.UnmarshalState[T](out.States[0].Object, &state); err != nil {
+		return state, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+
+	return state, nil
+}
+
+// decodeEventResult adapts DecodeEvent to the (output, error) pair returned
+// by the contract methods.
+func decodeEventResult[T any](out types.ContractOutput, err error, logType string) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return DecodeEvent[T](out, logType)
+}
+
+// decodeStateResult adapts DecodeState to the (output, error) pair returned
+// by the contract methods.
+func decodeStateResult[T any](out types.ContractOutput, err error) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return DecodeState[T](out)
+}
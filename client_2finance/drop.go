@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"gitlab.com/2finance/2finance-network/blockchain/contract/dropV1"
+	dropV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/dropV1/domain"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/dropV1/inputs"
+	dropV1Models "gitlab.com/2finance/2finance-network/blockchain/contract/dropV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/types"
 	"gitlab.com/2finance/2finance-network/blockchain/utils"
@@ -13,25 +15,25 @@ import (
 func (c *networkClient) NewDrop(in inputs.InputNewDrop) (types.ContractOutput, error) {
 
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 	if in.Owner == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner not set")
+		return types.ContractOutput{}, validationErrorf("owner not set")
 	}
 	if in.Title == "" {
-		return types.ContractOutput{}, fmt.Errorf("title not set")
+		return types.ContractOutput{}, validationErrorf("title not set")
 	}
 	if in.VerificationType == "" {
-		return types.ContractOutput{}, fmt.Errorf("verification type not set")
+		return types.ContractOutput{}, validationErrorf("verification type not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Owner); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	method := dropV1.METHOD_NEW_DROP
@@ -61,7 +63,7 @@ func (c *networkClient) NewDrop(in inputs.InputNewDrop) (types.ContractOutput, e
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, in.Address, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, in.Address, method, data, version, uuid7)
 }
 
 func (c *networkClient) UpdateDropMetadata(
@@ -69,10 +71,10 @@ func (c *networkClient) UpdateDropMetadata(
 ) (types.ContractOutput, error) {
 
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	method := dropV1.METHOD_UPDATE_DROP_METADATA
 	data := map[string]interface{}{
@@ -100,18 +102,18 @@ func (c *networkClient) UpdateDropMetadata(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, in.Address, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, in.Address, method, data, version, uuid7)
 }
 
 func (c *networkClient) AllowOracles(address string, oracles map[string]bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 	if len(oracles) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("oracles map is empty")
+		return types.ContractOutput{}, validationErrorf("oracles map is empty")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	method := dropV1.METHOD_ALLOW_ORACLES
 	version := uint8(1)
 	uuid7, err := utils.NewUUID7()
@@ -119,20 +121,20 @@ func (c *networkClient) AllowOracles(address string, oracles map[string]bool) (t
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, address, method, map[string]interface{}{
+	return c.SignAndSendTransaction(c.GetChainID(), from, address, method, map[string]interface{}{
 		"oracles": oracles,
 	}, version, uuid7)
 }
 
 func (c *networkClient) DisallowOracles(address string, oracles map[string]bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 	if len(oracles) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("oracles map is empty")
+		return types.ContractOutput{}, validationErrorf("oracles map is empty")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	method := dropV1.METHOD_DISALLOW_ORACLES
 	version := uint8(1)
 	uuid7, err := utils.NewUUID7()
@@ -140,7 +142,7 @@ func (c *networkClient) DisallowOracles(address string, oracles map[string]bool)
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, address, method, map[string]interface{}{
+	return c.SignAndSendTransaction(c.GetChainID(), from, address, method, map[string]interface{}{
 		"oracles": oracles,
 	}, version, uuid7)
 }
@@ -154,13 +156,13 @@ func (c *networkClient) DepositDrop(
 ) (types.ContractOutput, error) {
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	method := dropV1.METHOD_DEPOSIT_DROP
 	version := uint8(1)
 
@@ -169,7 +171,7 @@ func (c *networkClient) DepositDrop(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, address, method, map[string]interface{}{
+	return c.SignAndSendTransaction(c.GetChainID(), from, address, method, map[string]interface{}{
 		"program_address": programAddress,
 		"token_address":   tokenAddress,
 		"amount":          amount,
@@ -179,10 +181,10 @@ func (c *networkClient) DepositDrop(
 
 func (c *networkClient) ClaimDrop(address string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	method := dropV1.METHOD_CLAIM_DROP
 	version := uint8(1)
 
@@ -191,7 +193,7 @@ func (c *networkClient) ClaimDrop(address string) (types.ContractOutput, error)
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, address, method, map[string]interface{}{
+	return c.SignAndSendTransaction(c.GetChainID(), from, address, method, map[string]interface{}{
 		"address": address,
 		"wallet":  from,
 	}, version, uuid7)
@@ -206,20 +208,20 @@ func (c *networkClient) WithdrawDrop(
 ) (types.ContractOutput, error) {
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	method := dropV1.METHOD_WITHDRAW_DROP
 	version := uint8(1)
 	uuid7, err := utils.NewUUID7()
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, address, method, map[string]interface{}{
+	return c.SignAndSendTransaction(c.GetChainID(), from, address, method, map[string]interface{}{
 		"program_address": programAddress,
 		"token_address":   tokenAddress,
 		"amount":          amount,
@@ -229,18 +231,18 @@ func (c *networkClient) WithdrawDrop(
 
 func (c *networkClient) PauseDrop(dropAddress string) (types.ContractOutput, error) {
 	if dropAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(dropAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid drop address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid drop address: %w", err)
 	}
 
 	method := dropV1.METHOD_PAUSE_DROP
@@ -255,23 +257,23 @@ func (c *networkClient) PauseDrop(dropAddress string) (types.ContractOutput, err
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, dropAddress, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, dropAddress, method, data, version, uuid7)
 }
 
 func (c *networkClient) UnpauseDrop(dropAddress string) (types.ContractOutput, error) {
 	if dropAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(dropAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid drop address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid drop address: %w", err)
 	}
 
 	method := dropV1.METHOD_UNPAUSE_DROP
@@ -286,7 +288,7 @@ func (c *networkClient) UnpauseDrop(dropAddress string) (types.ContractOutput, e
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, dropAddress, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, dropAddress, method, data, version, uuid7)
 }
 
 func (c *networkClient) AttestParticipantEligibility(
@@ -296,16 +298,16 @@ func (c *networkClient) AttestParticipantEligibility(
 ) (types.ContractOutput, error) {
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 	if wallet == "" {
-		return types.ContractOutput{}, fmt.Errorf("wallet not set")
+		return types.ContractOutput{}, validationErrorf("wallet not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(wallet); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid wallet address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid wallet address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	method := dropV1.METHOD_ATTEST_ELIGIBILITY
 	version := uint8(1)
 
@@ -314,7 +316,7 @@ func (c *networkClient) AttestParticipantEligibility(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, address, method, map[string]interface{}{
+	return c.SignAndSendTransaction(c.GetChainID(), from, address, method, map[string]interface{}{
 		"wallet":   wallet,
 		"approved": approved,
 	}, version, uuid7)
@@ -326,22 +328,22 @@ func (c *networkClient) ManuallyAttestParticipantEligibility(
 	approved bool,
 ) (types.ContractOutput, error) {
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if dropAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address not set")
+		return types.ContractOutput{}, validationErrorf("drop address not set")
 	}
 	if wallet == "" {
-		return types.ContractOutput{}, fmt.Errorf("wallet not set")
+		return types.ContractOutput{}, validationErrorf("wallet not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(wallet); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid wallet address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid wallet address: %w", err)
 	}
 
 	method := dropV1.METHOD_MANUAL_ATTEST_ELIGIBILITY
@@ -356,7 +358,7 @@ func (c *networkClient) ManuallyAttestParticipantEligibility(
 	}
 
 	out, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		dropAddress,
 		method,
@@ -372,21 +374,21 @@ func (c *networkClient) ManuallyAttestParticipantEligibility(
 }
 
 func (c *networkClient) GetDrop(address string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address must be set")
+		return types.ContractOutput{}, validationErrorf("drop address must be set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid drop address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid drop address: %w", err)
 	}
 
 	method := dropV1.METHOD_GET_DROP
@@ -404,26 +406,26 @@ func (c *networkClient) ListDrops(
 	page, limit int,
 	ascending bool,
 ) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if owner != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(owner); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 		}
 	}
 
 	if page < 1 {
-		return types.ContractOutput{}, fmt.Errorf("page must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("page must be greater than 0")
 	}
 	if limit < 1 {
-		return types.ContractOutput{}, fmt.Errorf("limit must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("limit must be greater than 0")
 	}
 
 	method := dropV1.METHOD_LIST_DROPS
@@ -445,28 +447,28 @@ func (c *networkClient) ListDrops(
 }
 
 func (c *networkClient) LastClaimed(address string, wallet string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("drop address must be set")
+		return types.ContractOutput{}, validationErrorf("drop address must be set")
 	}
 	if wallet == "" {
-		return types.ContractOutput{}, fmt.Errorf("wallet must be set")
+		return types.ContractOutput{}, validationErrorf("wallet must be set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid drop address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid drop address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(wallet); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid wallet address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid wallet address: %w", err)
 	}
 
 	method := dropV1.METHOD_LAST_CLAIMED_DROP
@@ -482,3 +484,19 @@ func (c *networkClient) LastClaimed(address string, wallet string) (types.Contra
 
 	return contractOutput, nil
 }
+
+// DecodeDrop returns the drop logged by NewDrop, UpdateDropMetadata,
+// AllowOracles, DisallowOracles, PauseDrop or UnpauseDrop.
+func DecodeDrop(out types.ContractOutput, err error) (dropV1Domain.Drop, error) {
+	return decodeEventResult[dropV1Domain.Drop](out, err, "")
+}
+
+// DecodeDropClaim returns the claim performed by ClaimDrop.
+func DecodeDropClaim(out types.ContractOutput, err error) (dropV1Domain.Claim, error) {
+	return decodeEventResult[dropV1Domain.Claim](out, err, "")
+}
+
+// DecodeDropState returns the drop read by GetDrop.
+func DecodeDropState(out types.ContractOutput, err error) (dropV1Models.DropStateModel, error) {
+	return decodeStateResult[dropV1Models.DropStateModel](out, err)
+}
@@ -0,0 +1,91 @@
+package client_2finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+)
+
+var (
+	// ErrTimeout is returned when the node does not answer in time, either
+	// because the response timeout elapsed or the context deadline passed.
+	ErrTimeout = errors.New("timeout waiting for response")
+
+	// ErrValidation is returned when an argument is rejected before anything
+	// is sent to the node.
+	ErrValidation = errors.New("validation failed")
+
+	// ErrWalletLocked is returned when a transaction needs the private key
+	// and the wallet is locked. The error is a *wallet_manager.WalletLockedError
+	// naming the account and method.
+	ErrWalletLocked = wallet_manager.ErrWalletLocked
+
+	// ErrPasswordRequired is returned when the wallet requires its password
+	// for the method and the call was not made with WithPassword.
+	ErrPasswordRequired = wallet_manager.ErrPasswordRequired
+
+	// ErrMultisigThreshold is returned by SendMultisigTransaction while the
+	// transaction has fewer approvals than its threshold.
+	ErrMultisigThreshold = wallet_manager.ErrMultisigThreshold
+
+	// ErrIdempotencyConflict is returned when a call made with
+	// WithIdempotencyKey reuses the key of another operation.
+	ErrIdempotencyConflict = errors.New("idempotency key already used for another operation")
+)
+
+// ContractError is returned when the node answers with an error status.
+// Method is the contract method (or request method for reads) that failed
+// and Message is the reason reported by the node.
+type ContractError struct {
+	Method  string
+	Message string
+}
+
+func (e *ContractError) Error() string {
+	if e.Method == "" {
+		return fmt.Sprintf("error in response: %s", e.Message)
+	}
+
+	return fmt.Sprintf("error in response to %s: %s", e.Method, e.Message)
+}
+
+// validationError keeps the original message while matching ErrValidation.
+type validationError struct {
+	err error
+}
+
+func (e *validationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *validationError) Unwrap() []error {
+	return []error{ErrValidation, e.err}
+}
+
+// validationErrorf formats an error that matches ErrValidation.
+func validationErrorf(format string, args ...any) error {
+	return &validationError{err: fmt.Errorf(format, args...)}
+}
+
+// contextError marks a passed context deadline as ErrTimeout so callers can
+// treat it like the response timeout.
+func contextError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+
+	return err
+}
+
+// withContractMethod names method on a *ContractError in err, leaving any
+// other error untouched.
+func withContractMethod(err error, method string) error {
+	var contractErr *ContractError
+	if !errors.As(err, &contractErr) || contractErr.Method == method {
+		return err
+	}
+
+	return &ContractError{Method: method, Message: contractErr.Message}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	memberGetMemberV1 "gitlab.com/2finance/2finance-network/blockchain/contract/memberGetMemberV1"
+	mgmV1Models "gitlab.com/2finance/2finance-network/blockchain/contract/memberGetMemberV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/types"
@@ -21,34 +22,34 @@ func (c *networkClient) AddMgM(
 	expireAt time.Time,
 	paused bool,
 ) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if owner == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner not set")
+		return types.ContractOutput{}, validationErrorf("owner not set")
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if faucetAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("faucet address not set")
+		return types.ContractOutput{}, validationErrorf("faucet address not set")
 	}
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(owner); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(faucetAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid faucet address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid faucet address: %w", err)
 	}
 
 	to := address
@@ -70,7 +71,7 @@ func (c *networkClient) AddMgM(
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -91,13 +92,13 @@ func (c *networkClient) UpdateMgM(
 	startAt time.Time,
 	expireAt time.Time,
 ) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 
 	to := mgmAddress
@@ -116,7 +117,7 @@ func (c *networkClient) UpdateMgM(
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -133,20 +134,20 @@ func (c *networkClient) UpdateMgM(
 
 func (c *networkClient) PauseMgM(mgmAddress string, pause bool) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if !pause {
-		return types.ContractOutput{}, fmt.Errorf("pause must be true: Pause: %t", pause)
+		return types.ContractOutput{}, validationErrorf("pause must be true: Pause: %t", pause)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := mgmAddress
@@ -164,7 +165,7 @@ func (c *networkClient) PauseMgM(mgmAddress string, pause bool) (types.ContractO
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -181,20 +182,20 @@ func (c *networkClient) PauseMgM(mgmAddress string, pause bool) (types.ContractO
 
 func (c *networkClient) UnpauseMgM(mgmAddress string, pause bool) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if pause {
-		return types.ContractOutput{}, fmt.Errorf("pause must be false: Pause: %t", pause)
+		return types.ContractOutput{}, validationErrorf("pause must be false: Pause: %t", pause)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := mgmAddress
@@ -210,7 +211,7 @@ func (c *networkClient) UnpauseMgM(mgmAddress string, pause bool) (types.Contrac
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -232,29 +233,29 @@ func (c *networkClient) DepositMgM(
 	uuid string,
 ) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 
 	if tokenType == "" {
-		return types.ContractOutput{}, fmt.Errorf("tokenType not set")
+		return types.ContractOutput{}, validationErrorf("tokenType not set")
 	}
 	if tokenType == domain.NON_FUNGIBLE {
 		if uuid == "" {
-			return types.ContractOutput{}, fmt.Errorf("uuid must be set for non-fungible tokens")
+			return types.ContractOutput{}, validationErrorf("uuid must be set for non-fungible tokens")
 		}
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := mgmAddress
@@ -273,7 +274,7 @@ func (c *networkClient) DepositMgM(
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -295,29 +296,29 @@ func (c *networkClient) WithdrawMgM(
 	uuid string,
 ) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 
 	if tokenType == "" {
-		return types.ContractOutput{}, fmt.Errorf("tokenType not set")
+		return types.ContractOutput{}, validationErrorf("tokenType not set")
 	}
 	if tokenType == domain.NON_FUNGIBLE {
 		if uuid == "" {
-			return types.ContractOutput{}, fmt.Errorf("uuid must be set for non-fungible tokens")
+			return types.ContractOutput{}, validationErrorf("uuid must be set for non-fungible tokens")
 		}
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := mgmAddress
@@ -336,7 +337,7 @@ func (c *networkClient) WithdrawMgM(
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -353,20 +354,20 @@ func (c *networkClient) WithdrawMgM(
 
 func (c *networkClient) AddInviterMember(mgmAddress string, inviterAddress string, password string) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if password == "" {
-		return types.ContractOutput{}, fmt.Errorf("password not set")
+		return types.ContractOutput{}, validationErrorf("password not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := mgmAddress
@@ -385,7 +386,7 @@ func (c *networkClient) AddInviterMember(mgmAddress string, inviterAddress strin
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -402,19 +403,19 @@ func (c *networkClient) AddInviterMember(mgmAddress string, inviterAddress strin
 
 func (c *networkClient) UpdateInviterPassword(mgmAddress string, inviterAddress string, newPassword string) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if newPassword == "" {
-		return types.ContractOutput{}, fmt.Errorf("new password not set")
+		return types.ContractOutput{}, validationErrorf("new password not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	method := memberGetMemberV1.METHOD_UPDATE_INVITER_PASSWORD
@@ -431,7 +432,7 @@ func (c *networkClient) UpdateInviterPassword(mgmAddress string, inviterAddress
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -448,16 +449,16 @@ func (c *networkClient) UpdateInviterPassword(mgmAddress string, inviterAddress
 
 func (c *networkClient) DeleteInviterMember(mgmAddress string, inviterAddress string) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := mgmAddress
@@ -474,7 +475,7 @@ func (c *networkClient) DeleteInviterMember(mgmAddress string, inviterAddress st
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -491,20 +492,20 @@ func (c *networkClient) DeleteInviterMember(mgmAddress string, inviterAddress st
 
 func (c *networkClient) ClaimReward(mgmAddress, invitedAddress, password string) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if password == "" {
-		return types.ContractOutput{}, fmt.Errorf("password not set")
+		return types.ContractOutput{}, validationErrorf("password not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := mgmAddress
@@ -522,7 +523,7 @@ func (c *networkClient) ClaimReward(mgmAddress, invitedAddress, password string)
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -539,16 +540,16 @@ func (c *networkClient) ClaimReward(mgmAddress, invitedAddress, password string)
 
 func (c *networkClient) GetMgM(mgmAddress string) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	method := memberGetMemberV1.METHOD_GET_MGM
@@ -563,23 +564,23 @@ func (c *networkClient) GetMgM(mgmAddress string) (types.ContractOutput, error)
 
 func (c *networkClient) GetInviterMember(mgmAddress string, inviterAddress string) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if inviterAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("inviter address not set")
+		return types.ContractOutput{}, validationErrorf("inviter address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(inviterAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid inviter address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid inviter address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	method := memberGetMemberV1.METHOD_GET_INVITER_MEMBER
@@ -598,23 +599,23 @@ func (c *networkClient) GetInviterMember(mgmAddress string, inviterAddress strin
 
 func (c *networkClient) GetClaimInviter(mgmAddress string, inviterAddress string) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if inviterAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("inviter address not set")
+		return types.ContractOutput{}, validationErrorf("inviter address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(inviterAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid inviter address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid inviter address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	method := memberGetMemberV1.METHOD_GET_CLAIM_INVITER
@@ -633,23 +634,23 @@ func (c *networkClient) GetClaimInviter(mgmAddress string, inviterAddress string
 
 func (c *networkClient) GetClaimInvited(mgmAddress string, invitedAddress string) (types.ContractOutput, error) {
 	if mgmAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mgmAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if invitedAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("invited address not set")
+		return types.ContractOutput{}, validationErrorf("invited address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(invitedAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid invited address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid invited address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	method := memberGetMemberV1.METHOD_GET_CLAIM_INVITED
@@ -665,3 +666,8 @@ func (c *networkClient) GetClaimInvited(mgmAddress string, invitedAddress string
 
 	return contractOutput, nil
 }
+
+// DecodeMgMState returns the member-get-member program read by GetMgM.
+func DecodeMgMState(out types.ContractOutput, err error) (mgmV1Models.MgMStateModel, error) {
+	return decodeStateResult[mgmV1Models.MgMStateModel](out, err)
+}
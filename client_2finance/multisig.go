@@ -0,0 +1,73 @@
+package client_2finance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+)
+
+// SendMultisigTransaction signs m for its From account with the signer bound
+// to ctx, or the client's signer, and sends it. It fails with
+// ErrMultisigThreshold, before anything is signed, until enough of m's
+// signers have approved it.
+//
+// Build m with wallet_manager.NewMultisigTransaction, serialize it with
+// json.Marshal to pass it around, and collect approvals with Approve or
+// AddApproval; the method arguments are the ones of the write method the
+// transaction stands for, such as RevokeMintAuthority or WithdrawDrop.
+//
+// The approvals bind only when the From key refuses to sign without them:
+// hold it in a signing daemon serving wallet_manager.RemoteSignerHandler with
+// WithMultisigOnly, which checks the threshold again before it signs.
+func (c *networkClient) SendMultisigTransaction(ctx context.Context, m *wallet_manager.MultisigTransaction) (types.ContractOutput, error) {
+	if m == nil {
+		return types.ContractOutput{}, validationErrorf("multisig transaction is required")
+	}
+	if ctx == nil {
+		ctx = c.context()
+	}
+
+	if err := m.CheckThreshold(); err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	signer := c.signerFor(ctx)
+	if signer == nil {
+		return types.ContractOutput{}, validationErrorf("wallet manager is required")
+	}
+
+	txSigned, err := c.signMultisigTransaction(ctx, signer, m)
+	if err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	return c.sendContractTransaction(ctx, txSigned)
+}
+
+// signMultisigTransaction signs m with signer, handing the whole of m to a
+// wallet_manager.MultisigSigner so that it checks the approvals itself.
+func (c *networkClient) signMultisigTransaction(ctx context.Context, signer wallet_manager.Signer, m *wallet_manager.MultisigTransaction) (*transaction.Transaction, error) {
+	if multisigSigner, ok := signer.(wallet_manager.MultisigSigner); ok {
+		txSigned, err := multisigSigner.SignMultisigTransaction(m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		return txSigned, nil
+	}
+
+	data, err := m.TransactionData()
+	if err != nil {
+		return nil, validationErrorf("invalid multisig transaction: %w", err)
+	}
+
+	tx := m.Transaction
+	txSigned, err := c.signTransaction(ctx, signer, tx.ChainID, tx.From, tx.To, tx.Method, data, tx.Version, tx.UUID7)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return txSigned, nil
+}
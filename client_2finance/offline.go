@@ -0,0 +1,295 @@
+package client_2finance
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/base32"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+	"gitlab.com/2finance/2finance-network/blockchain/utils"
+)
+
+// OfflineTransactionPrefix starts every encoded offline transaction. The
+// digit is the version of the encoding.
+const OfflineTransactionPrefix = "2FTX1:"
+
+// offlineEncoding is unpadded base32: upper-case letters and digits only,
+// which QR codes store in their compact alphanumeric mode and which survive
+// copy and paste, e-mail and text files.
+var offlineEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// builderKey is the context key BuildTransaction stores its builder under.
+type builderKey struct{}
+
+// transactionBuilder collects the transaction a contract method would have
+// signed and sent.
+type transactionBuilder struct {
+	tx *transaction.Transaction
+}
+
+// offlineTransaction is the encoded form of a transaction. Its fields are
+// spelled out, rather than taken from transaction.Transaction, so that the
+// encoding stays the same whatever the network adds to that type.
+type offlineTransaction struct {
+	ChainID   uint8           `json:"chain_id"`
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Method    string          `json:"method"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Version   uint8           `json:"version"`
+	UUID7     string          `json:"uuid7"`
+	Hash      string          `json:"hash,omitempty"`
+	Signature string          `json:"signature,omitempty"`
+}
+
+// BuildTransaction returns, encoded for SignTransactionOffline, the unsigned
+// transaction call makes when it calls a contract method on the client it is
+// given, for example
+//
+//	unsigned, err := c.BuildTransaction(coldWallet, func(c client_2finance.Client2FinanceNetwork) (types.ContractOutput, error) {
+//		return c.TransferToken(token, payee, "10", nil)
+//	})
+//
+// The method runs its usual checks with from, a public key, as the sender,
+// but nothing is signed or sent: no private key is needed. call must make
+// exactly one contract call.
+//
+// The transaction is not executed either. Previewing its output, logs or
+// contract error needs the node to run a transaction without committing it,
+// and the node offers no request method for that yet.
+func (c *networkClient) BuildTransaction(from string, call func(Client2FinanceNetwork) (types.ContractOutput, error)) (string, error) {
+	if call == nil {
+		return "", validationErrorf("call is required")
+	}
+	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
+		return "", validationErrorf("invalid from address: %w", err)
+	}
+
+	tx, err := c.buildTransaction(WithSigner(c.context(), watchOnlySigner(from)), call)
+	if err != nil {
+		return "", err
+	}
+
+	return EncodeOfflineTransaction(tx)
+}
+
+// buildTransaction returns the unsigned transaction of the one contract call
+// call makes on the client bound to ctx.
+func (c *networkClient) buildTransaction(ctx context.Context, call func(Client2FinanceNetwork) (types.ContractOutput, error)) (*transaction.Transaction, error) {
+	builder := &transactionBuilder{}
+	ctx = context.WithValue(ctx, builderKey{}, builder)
+
+	if _, err := call(c.withContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	if builder.tx == nil {
+		return nil, validationErrorf("call made no contract call")
+	}
+
+	return builder.tx, nil
+}
+
+// BroadcastSignedTransaction sends a transaction signed by
+// SignTransactionOffline. A nil ctx uses the context the client is bound to.
+func (c *networkClient) BroadcastSignedTransaction(ctx context.Context, signed string) (types.ContractOutput, error) {
+	if ctx == nil {
+		ctx = c.context()
+	}
+
+	tx, err := DecodeOfflineTransaction(signed)
+	if err != nil {
+		return types.ContractOutput{}, validationErrorf("invalid signed transaction: %w", err)
+	}
+
+	if tx.Hash == "" || tx.Signature == "" {
+		return types.ContractOutput{}, validationErrorf("transaction is not signed")
+	}
+
+	if err := keys.ValidateEDDSAPublicKeyHex(tx.From); err != nil {
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
+	}
+
+	return c.sendContractTransaction(ctx, tx)
+}
+
+// SignTransactionOffline signs a transaction encoded by BuildTransaction and
+// returns it encoded for BroadcastSignedTransaction. It makes no network call,
+// so it runs where the wallet is kept, such as an air-gapped machine. password
+// is handed to signers that take one, like WithPassword does; leave it empty
+// to sign with an unlocked wallet.
+func SignTransactionOffline(signer wallet_manager.Signer, password string, unsigned string) (string, error) {
+	if signer == nil {
+		return "", validationErrorf("wallet manager is required")
+	}
+
+	tx, err := DecodeOfflineTransaction(unsigned)
+	if err != nil {
+		return "", validationErrorf("invalid unsigned transaction: %w", err)
+	}
+
+	if tx.Hash != "" || tx.Signature != "" {
+		return "", validationErrorf("transaction is already signed")
+	}
+
+	data, err := transactionData(tx)
+	if err != nil {
+		return "", validationErrorf("%w", err)
+	}
+
+	txSigned, err := signWithPassword(signer, password, tx.ChainID, tx.From, tx.To, tx.Method, data, tx.Version, tx.UUID7)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return EncodeOfflineTransaction(txSigned)
+}
+
+// EncodeOfflineTransaction encodes tx, signed or not, as
+// OfflineTransactionPrefix followed by its compressed JSON in unpadded base32.
+// The result fits a file or a QR code; zlib's checksum catches damaged copies.
+func EncodeOfflineTransaction(tx *transaction.Transaction) (string, error) {
+	if tx == nil {
+		return "", errors.New("transaction is required")
+	}
+
+	payload, err := json.Marshal(offlineTransaction{
+		ChainID:   tx.ChainID,
+		From:      tx.From,
+		To:        tx.To,
+		Method:    tx.Method,
+		Data:      tx.Data,
+		Version:   tx.Version,
+		UUID7:     tx.UUID7,
+		Hash:      tx.Hash,
+		Signature: tx.Signature,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+
+	var compressed bytes.Buffer
+	zw, err := zlib.NewWriterLevel(&compressed, zlib.BestCompression)
+	if err != nil {
+		return "", fmt.Errorf("failed to compress transaction: %w", err)
+	}
+	if _, err := zw.Write(payload); err != nil {
+		return "", fmt.Errorf("failed to compress transaction: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress transaction: %w", err)
+	}
+
+	return OfflineTransactionPrefix + offlineEncoding.EncodeToString(compressed.Bytes()), nil
+}
+
+// DecodeOfflineTransaction decodes a transaction encoded by
+// EncodeOfflineTransaction, to show it before it is signed or sent.
+// Whitespace, such as the line breaks of a wrapped file, is ignored.
+func DecodeOfflineTransaction(encoded string) (*transaction.Transaction, error) {
+	encoded = strings.Join(strings.Fields(encoded), "")
+
+	body, ok := strings.CutPrefix(encoded, OfflineTransactionPrefix)
+	if !ok {
+		return nil, fmt.Errorf("not an offline transaction: want prefix %s", OfflineTransactionPrefix)
+	}
+
+	compressed, err := offlineEncoding.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress transaction: %w", err)
+	}
+	defer zr.Close()
+
+	payload, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress transaction: %w", err)
+	}
+
+	var decoded offlineTransaction
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
+	}
+
+	return &transaction.Transaction{
+		ChainID:   decoded.ChainID,
+		From:      decoded.From,
+		To:        decoded.To,
+		Method:    decoded.Method,
+		Data:      decoded.Data,
+		Version:   decoded.Version,
+		UUID7:     decoded.UUID7,
+		Hash:      decoded.Hash,
+		Signature: decoded.Signature,
+	}, nil
+}
+
+// builderFor returns the builder set on ctx or on the context the client is
+// bound to by BuildTransaction.
+func (c *networkClient) builderFor(ctx context.Context) (*transactionBuilder, bool) {
+	for _, ctx := range []context.Context{ctx, c.context()} {
+		if ctx == nil {
+			continue
+		}
+		if builder, ok := ctx.Value(builderKey{}).(*transactionBuilder); ok {
+			return builder, true
+		}
+	}
+	return nil, false
+}
+
+// add records the transaction in place of signing and sending it.
+func (b *transactionBuilder) add(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) error {
+	if b.tx != nil {
+		return validationErrorf("BuildTransaction builds one transaction per call")
+	}
+
+	tx, err := wallet_manager.NewUnsignedTransaction(chainId, from, to, method, data, version, uuid7)
+	if err != nil {
+		return err
+	}
+	b.tx = tx
+
+	return nil
+}
+
+// transactionData returns the data of tx in the form signers take it.
+func transactionData(tx *transaction.Transaction) (utils.JSONB, error) {
+	var data utils.JSONB
+	if len(tx.Data) > 0 {
+		if err := json.Unmarshal(tx.Data, &data); err != nil {
+			return nil, fmt.Errorf("invalid transaction data: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// watchOnlySigner stands for an account whose key is elsewhere: it gives
+// contract methods their sender while a transaction is built, and cannot
+// sign.
+type watchOnlySigner string
+
+func (s watchOnlySigner) GetPublicKey() string {
+	return string(s)
+}
+
+func (s watchOnlySigner) IsUnlocked() bool {
+	return false
+}
+
+func (s watchOnlySigner) SignTransaction(_ uint8, _, _, method string, _ utils.JSONB, _ uint8, _ string) (*transaction.Transaction, error) {
+	return nil, &wallet_manager.WalletLockedError{Owner: string(s), Method: method}
+}
@@ -0,0 +1,208 @@
+package client_2finance
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"github.com/google/uuid"
+)
+
+const (
+	// ChainIDMainnet and ChainIDTestnet are the chain IDs accepted by the
+	// network.
+	ChainIDMainnet uint8 = 1
+	ChainIDTestnet uint8 = 2
+)
+
+// clientIDMaxLength is the longest client identifier MQTT brokers must accept.
+const clientIDMaxLength = 65535
+
+// Option configures a client built by New.
+type Option func(*options) error
+
+type options struct {
+	clientID        string
+	debug           bool
+	chainId         uint8
+	signer          wallet_manager.Signer
+	responseTimeout time.Duration
+	retryPolicy     RetryPolicy
+	outbox          *Outbox
+}
+
+// WithClientID sets the MQTT client ID. A random ID is used when omitted.
+func WithClientID(clientID string) Option {
+	return func(o *options) error {
+		if err := validateClientID(clientID); err != nil {
+			return err
+		}
+		o.clientID = clientID
+		return nil
+	}
+}
+
+// WithDebug enables the MQTT client debug output.
+func WithDebug(debug bool) Option {
+	return func(o *options) error {
+		o.debug = debug
+		return nil
+	}
+}
+
+// WithChainID sets the chain transactions are signed for. It is required.
+func WithChainID(chainId uint8) Option {
+	return func(o *options) error {
+		if err := validateChainID(chainId); err != nil {
+			return err
+		}
+		o.chainId = chainId
+		return nil
+	}
+}
+
+// WithWalletManager sets the wallet used to sign transactions.
+func WithWalletManager(walletManager wallet_manager.IWalletManager) Option {
+	return func(o *options) error {
+		if err := validateWalletManager(walletManager); err != nil {
+			return err
+		}
+		o.signer = walletManager
+		return nil
+	}
+}
+
+// WithTransactionSigner sets the signer of transactions, such as a
+// wallet_manager.RemoteSigner or wallet_manager.PKCS11Signer, in place of a
+// local wallet manager.
+func WithTransactionSigner(signer wallet_manager.Signer) Option {
+	return func(o *options) error {
+		if err := validateSigner(signer); err != nil {
+			return err
+		}
+		o.signer = signer
+		return nil
+	}
+}
+
+// WithResponseTimeout sets how long a request waits for the node to answer
+// when the context has no earlier deadline.
+func WithResponseTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		if timeout <= 0 {
+			return validationErrorf("response timeout must be greater than 0: %s", timeout)
+		}
+		o.responseTimeout = timeout
+		return nil
+	}
+}
+
+// WithRetryPolicy makes SignAndSendTransaction re-send a transaction whose
+// response timed out, following policy. By default transactions are sent
+// once; DefaultRetryPolicy is a reasonable starting point.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) error {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		o.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithOutbox makes the client record the transactions it sends in outbox,
+// assigning their uuid7 before signing, so that they can be resubmitted with
+// ResubmitOutbox and that calls made with WithIdempotencyKey are not signed
+// twice.
+func WithOutbox(outbox *Outbox) Option {
+	return func(o *options) error {
+		if outbox == nil {
+			return validationErrorf("outbox is required")
+		}
+		o.outbox = outbox
+		return nil
+	}
+}
+
+func defaultOptions() options {
+	return options{
+		clientID:        fmt.Sprintf("2finance-%s", uuid.NewString()),
+		responseTimeout: defaultResponseTimeout,
+		retryPolicy:     noRetryPolicy,
+	}
+}
+
+func newOptions(opts []Option) (options, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return options{}, err
+		}
+	}
+	if o.chainId == 0 {
+		return options{}, validationErrorf("chain ID not set: use WithChainID")
+	}
+	return o, nil
+}
+
+func validateChainID(chainId uint8) error {
+	if chainId != ChainIDMainnet && chainId != ChainIDTestnet {
+		return validationErrorf("invalid chainId: %d, available values are 2 testnet or 1 mainnet", chainId)
+	}
+	return nil
+}
+
+func validateWalletManager(walletManager wallet_manager.IWalletManager) error {
+	if walletManager == nil {
+		return validationErrorf("wallet manager cannot be nil")
+	}
+	return nil
+}
+
+func validateSigner(signer wallet_manager.Signer) error {
+	if signer == nil {
+		return validationErrorf("signer cannot be nil")
+	}
+	return nil
+}
+
+func validateClientID(clientID string) error {
+	if strings.TrimSpace(clientID) == "" {
+		return validationErrorf("client ID not set")
+	}
+
+	if len(clientID) > clientIDMaxLength {
+		return validationErrorf("client ID longer than %d bytes", clientIDMaxLength)
+	}
+
+	if strings.ContainsAny(clientID, "+#/") {
+		return validationErrorf("invalid client ID: %q must not contain '+', '#' or '/'", clientID)
+	}
+
+	return nil
+}
+
+func validateBrokerURL(broker string) error {
+	if broker == "" {
+		return validationErrorf("broker URL not set")
+	}
+
+	u, err := url.Parse(broker)
+	if err != nil {
+		return validationErrorf("invalid broker URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "tcp", "ssl", "tls", "mqtt", "mqtts", "ws", "wss":
+	default:
+		return validationErrorf("invalid broker URL: unsupported scheme %q", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return validationErrorf("invalid broker URL: host not set")
+	}
+
+	return nil
+}
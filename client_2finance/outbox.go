@@ -0,0 +1,529 @@
+package client_2finance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+)
+
+// OutboxStatus is where an outbox entry is in its life.
+type OutboxStatus string
+
+const (
+	// OutboxStatusNew entries have their uuid7 assigned but are not signed
+	// yet.
+	OutboxStatusNew OutboxStatus = "new"
+	// OutboxStatusSent entries are signed and were sent at least once, but
+	// their outcome is unknown, for example because the response timed out
+	// or the process stopped.
+	OutboxStatusSent OutboxStatus = "sent"
+	// OutboxStatusCommitted entries were committed by the node.
+	OutboxStatusCommitted OutboxStatus = "committed"
+	// OutboxStatusFailed entries were rejected by the contract.
+	OutboxStatusFailed OutboxStatus = "failed"
+)
+
+// idempotencyKey is the context key WithIdempotencyKey stores the key under.
+type idempotencyKey struct{}
+
+// OutboxEntry is the local record of one logical operation.
+type OutboxEntry struct {
+	// Key is the idempotency key of the call, or the transaction's uuid7
+	// when the call had none. Such unkeyed entries are removed once their
+	// outcome is known.
+	Key    string       `json:"key"`
+	Status OutboxStatus `json:"status"`
+
+	// Transaction is unsigned while the entry is new and signed afterwards.
+	// Its uuid7, and so its hash, never change.
+	Transaction transaction.Transaction `json:"transaction"`
+
+	// Output is the contract output of a committed transaction, rebuilt
+	// from its logs when the node's response was lost; Error is the
+	// contract's reason for rejecting a failed one.
+	Output json.RawMessage `json:"output,omitempty"`
+	Error  string          `json:"error,omitempty"`
+
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Outbox persists the transactions a client sends, one JSON file per
+// operation in a directory. With an outbox set by WithOutbox, every contract
+// method records its transaction, with the uuid7 it is signed with, before
+// signing it, and records the outcome once the node answers:
+//
+//   - a call made with WithIdempotencyKey and a key already in the outbox
+//     does not sign again: it returns the recorded output or contract error,
+//     or re-sends the recorded signed transaction when its outcome is unknown;
+//   - ResubmitOutbox re-sends, after a restart for example, every transaction
+//     whose outcome is unknown.
+//
+// The entries of calls made without an idempotency key cannot be looked up
+// by a later call, so they are kept only until their transaction is
+// committed or rejected.
+//
+// An Outbox is safe for concurrent use, and processes may share its
+// directory: the calls for one key are serialized through a lock file next to
+// its entry, and a call waiting too long for another process fails with
+// wallet_manager.ErrFileLocked.
+type Outbox struct {
+	dir string
+
+	mu    sync.Mutex
+	locks map[string]*outboxLock
+}
+
+// outboxLock serializes the calls for one key within the process.
+type outboxLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// OpenOutbox opens the outbox kept in dir, creating the directory if needed.
+func OpenOutbox(dir string) (*Outbox, error) {
+	if dir == "" {
+		return nil, validationErrorf("outbox directory is required")
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	return &Outbox{dir: dir, locks: make(map[string]*outboxLock)}, nil
+}
+
+// WithIdempotencyKey returns a copy of ctx that names the operation of the
+// contract call made with it. Calls with the same key and an outbox set are
+// one operation: the transaction is signed once, with one uuid7, whatever the
+// number of calls, and a call with the key and different arguments fails with
+// ErrIdempotencyConflict. Without an outbox the key is ignored.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// idempotencyKeyFor returns the key set on ctx or on the context the client
+// is bound to.
+func (c *networkClient) idempotencyKeyFor(ctx context.Context) (string, bool) {
+	for _, ctx := range []context.Context{ctx, c.context()} {
+		if ctx == nil {
+			continue
+		}
+		if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// Entry returns the entry recorded under key.
+func (o *Outbox) Entry(key string) (OutboxEntry, bool, error) {
+	data, err := os.ReadFile(o.entryPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return OutboxEntry{}, false, nil
+	}
+	if err != nil {
+		return OutboxEntry{}, false, fmt.Errorf("failed to read outbox entry: %w", err)
+	}
+
+	var entry OutboxEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return OutboxEntry{}, false, fmt.Errorf("failed to unmarshal outbox entry: %w", err)
+	}
+
+	return entry, true, nil
+}
+
+// Entries returns every entry, oldest first.
+func (o *Outbox) Entries() ([]OutboxEntry, error) {
+	files, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox directory: %w", err)
+	}
+
+	entries := make([]OutboxEntry, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(o.dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read outbox entry: %w", err)
+		}
+
+		var entry OutboxEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox entry %s: %w", file.Name(), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	slices.SortStableFunc(entries, func(a, b OutboxEntry) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return entries, nil
+}
+
+// Remove deletes the entry recorded under key, once it no longer needs to be
+// remembered.
+func (o *Outbox) Remove(key string) error {
+	unlock, err := o.lock(key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return o.remove(key)
+}
+
+// remove deletes the entry of key. The caller holds the lock of key.
+func (o *Outbox) remove(key string) error {
+	if err := os.Remove(o.entryPath(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove outbox entry: %w", err)
+	}
+
+	return nil
+}
+
+// forget deletes the entry of an unkeyed call and its lock file. No other
+// call can use the entry's uuid7 as a key, so nothing waits on the lock. The
+// caller holds the lock of key.
+func (o *Outbox) forget(key string) error {
+	if err := o.remove(key); err != nil {
+		return err
+	}
+	_ = os.Remove(o.entryPath(key) + ".lock")
+
+	return nil
+}
+
+// entryPath names the file of key after its hash, so that any key is a safe
+// file name.
+func (o *Outbox) entryPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(o.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// put writes entry atomically, so that a crash leaves either the old or the
+// new entry on disk.
+func (o *Outbox) put(entry OutboxEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox entry: %w", err)
+	}
+
+	if err := wallet_manager.WriteFileAtomic(o.entryPath(entry.Key), data, 0600); err != nil {
+		return fmt.Errorf("failed to write outbox entry: %w", err)
+	}
+
+	return nil
+}
+
+// lock serializes the calls for key, within the process and with the other
+// processes sharing the directory, and returns the unlock function.
+func (o *Outbox) lock(key string) (func(), error) {
+	o.mu.Lock()
+	l := o.locks[key]
+	if l == nil {
+		l = &outboxLock{}
+		o.locks[key] = l
+	}
+	l.refs++
+	o.mu.Unlock()
+
+	l.mu.Lock()
+
+	release := func() {
+		l.mu.Unlock()
+
+		o.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(o.locks, key)
+		}
+		o.mu.Unlock()
+	}
+
+	unlockFile, err := wallet_manager.LockFile(o.entryPath(key))
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to lock outbox entry: %w", err)
+	}
+
+	return func() {
+		unlockFile()
+		release()
+	}, nil
+}
+
+// ResubmitOutbox re-sends the signed transactions of the outbox whose
+// outcome is unknown and returns their entries as updated. A transaction the
+// node already committed is marked so without being sent again. New entries
+// were never signed, so nothing was sent: repeat their call with the same
+// idempotency key to complete them.
+func (c *networkClient) ResubmitOutbox(ctx context.Context) ([]OutboxEntry, error) {
+	if c.outbox == nil {
+		return nil, validationErrorf("outbox is required")
+	}
+	if ctx == nil {
+		ctx = c.context()
+	}
+
+	entries, err := c.outbox.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var resubmitted []OutboxEntry
+	var errs []error
+	for _, entry := range entries {
+		if entry.Status != OutboxStatusSent {
+			continue
+		}
+
+		updated, found, err := c.resubmitOutboxEntry(ctx, entry.Key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resubmit %s: %w", entry.Key, err))
+		}
+		if found {
+			resubmitted = append(resubmitted, updated)
+		}
+	}
+
+	return resubmitted, errors.Join(errs...)
+}
+
+// resubmitOutboxEntry re-sends the entry under key if its outcome is still
+// unknown, and reports whether it did. Contract failures are outcomes, not
+// errors, here.
+func (c *networkClient) resubmitOutboxEntry(ctx context.Context, key string) (OutboxEntry, bool, error) {
+	unlock, err := c.outbox.lock(key)
+	if err != nil {
+		return OutboxEntry{}, false, err
+	}
+	defer unlock()
+
+	entry, found, err := c.outbox.Entry(key)
+	if err != nil || !found || entry.Status != OutboxStatusSent {
+		return OutboxEntry{}, false, err
+	}
+
+	_, err = c.submitOutboxEntry(ctx, &entry, true)
+
+	var contractErr *ContractError
+	if errors.As(err, &contractErr) {
+		err = nil
+	}
+
+	return entry, true, err
+}
+
+// sendThroughOutbox is the outbox path of SignAndSendTransactionContext. The
+// caller has validated the arguments.
+func (c *networkClient) sendThroughOutbox(
+	ctx context.Context,
+	signer wallet_manager.Signer,
+	chainId uint8,
+	from, to, method string,
+	data map[string]interface{},
+	version uint8,
+	uuid7 string,
+) (types.ContractOutput, error) {
+	key, ok := c.idempotencyKeyFor(ctx)
+	if !ok {
+		key = uuid7
+	}
+
+	unlock, err := c.outbox.lock(key)
+	if err != nil {
+		return types.ContractOutput{}, err
+	}
+	defer unlock()
+
+	tx, err := wallet_manager.NewUnsignedTransaction(chainId, from, to, method, data, version, uuid7)
+	if err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	entry, found, err := c.outbox.Entry(key)
+	if err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	if found {
+		if !sameOperation(entry.Transaction, *tx) {
+			return types.ContractOutput{}, fmt.Errorf("%w: %s", ErrIdempotencyConflict, key)
+		}
+	} else {
+		now := time.Now().UTC()
+		entry = OutboxEntry{
+			Key:         key,
+			Status:      OutboxStatusNew,
+			Transaction: *tx,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := c.outbox.put(entry); err != nil {
+			return types.ContractOutput{}, err
+		}
+	}
+
+	switch entry.Status {
+	case OutboxStatusCommitted:
+		return c.outboxOutput(ctx, &entry)
+	case OutboxStatusFailed:
+		return types.ContractOutput{}, &ContractError{Method: entry.Transaction.Method, Message: entry.Error}
+	case OutboxStatusSent:
+		return c.submitOutboxEntry(ctx, &entry, true)
+	}
+
+	// The entry is new: sign it with the uuid7 recorded first.
+	unsigned := entry.Transaction
+	unsignedData, err := transactionData(&unsigned)
+	if err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	txSigned, err := c.signTransaction(ctx, signer, unsigned.ChainID, unsigned.From, unsigned.To, unsigned.Method, unsignedData, unsigned.Version, unsigned.UUID7)
+	if err != nil {
+		if !entry.keyed() {
+			// Nothing was sent, and no later call can complete the entry.
+			_ = c.outbox.forget(key)
+		}
+		return types.ContractOutput{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	entry.Transaction = *txSigned
+	entry.Status = OutboxStatusSent
+	entry.UpdatedAt = time.Now().UTC()
+	if err := c.outbox.put(entry); err != nil {
+		return types.ContractOutput{}, err
+	}
+
+	return c.submitOutboxEntry(ctx, &entry, false)
+}
+
+// submitOutboxEntry sends the signed transaction of entry and records the
+// outcome. A resend first asks the node whether an earlier attempt was
+// committed.
+func (c *networkClient) submitOutboxEntry(ctx context.Context, entry *OutboxEntry, resend bool) (types.ContractOutput, error) {
+	var out types.ContractOutput
+	var err error
+
+	committed := false
+	if resend {
+		committed, _ = c.transactionCommitted(ctx, entry.Transaction.Hash)
+	}
+
+	if committed {
+		entry.Status = OutboxStatusCommitted
+		out, err = c.committedOutput(ctx, entry.Transaction.Hash)
+	} else {
+		entry.Attempts++
+		out, err = c.sendContractTransaction(ctx, &entry.Transaction)
+	}
+
+	var contractErr *ContractError
+	switch {
+	case err == nil:
+		entry.Status = OutboxStatusCommitted
+		if output, marshalErr := json.Marshal(out); marshalErr == nil {
+			entry.Output = output
+		}
+	case entry.Status == OutboxStatusCommitted:
+		// The transaction was committed but its output could not be read;
+		// it is read again the next time the entry is used.
+	case errors.As(err, &contractErr):
+		entry.Status = OutboxStatusFailed
+		entry.Error = contractErr.Message
+	default:
+		// The outcome is unknown: the entry stays sent for ResubmitOutbox.
+	}
+	entry.UpdatedAt = time.Now().UTC()
+
+	if entry.Status != OutboxStatusSent && !entry.keyed() {
+		// Nothing can ask for the outcome of an unkeyed entry again.
+		if removeErr := c.outbox.forget(entry.Key); removeErr != nil && err == nil {
+			return out, removeErr
+		}
+		return out, err
+	}
+
+	if putErr := c.outbox.put(*entry); putErr != nil && err == nil {
+		return out, fmt.Errorf("transaction %s was sent but not recorded: %w", entry.Transaction.Hash, putErr)
+	}
+
+	return out, err
+}
+
+// keyed reports whether entry was recorded under an idempotency key rather
+// than its uuid7.
+func (e *OutboxEntry) keyed() bool {
+	return e.Key != e.Transaction.UUID7
+}
+
+// outboxOutput returns the recorded contract output of the committed entry,
+// rebuilding it from the transaction's logs when it was not recorded.
+func (c *networkClient) outboxOutput(ctx context.Context, entry *OutboxEntry) (types.ContractOutput, error) {
+	if len(entry.Output) == 0 {
+		out, err := c.committedOutput(ctx, entry.Transaction.Hash)
+		if err != nil {
+			return types.ContractOutput{}, err
+		}
+
+		if output, marshalErr := json.Marshal(out); marshalErr == nil {
+			entry.Output = output
+			entry.UpdatedAt = time.Now().UTC()
+			if err := c.outbox.put(*entry); err != nil {
+				return out, err
+			}
+		}
+
+		return out, nil
+	}
+
+	var out types.ContractOutput
+	if err := json.Unmarshal(entry.Output, &out); err != nil {
+		return types.ContractOutput{}, fmt.Errorf("failed to unmarshal recorded contract output: %w", err)
+	}
+
+	return out, nil
+}
+
+// sameOperation reports whether two transactions do the same thing, whatever
+// their uuid7 and signature.
+func sameOperation(a, b transaction.Transaction) bool {
+	if a.ChainID != b.ChainID || a.From != b.From || a.To != b.To || a.Method != b.Method || a.Version != b.Version {
+		return false
+	}
+
+	var dataA, dataB interface{}
+	if len(a.Data) > 0 && json.Unmarshal(a.Data, &dataA) != nil {
+		return false
+	}
+	if len(b.Data) > 0 && json.Unmarshal(b.Data, &dataB) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(dataA, dataB)
+}
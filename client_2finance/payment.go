@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1/domain"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1/inputs"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/paymentV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/types"
 	"gitlab.com/2finance/2finance-network/blockchain/utils"
@@ -12,59 +14,59 @@ import (
 
 // CreatePayment creates a new payment intent.
 func (c *networkClient) CreatePayment(in inputs.InputCreate) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if in.Owner == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner not set")
+		return types.ContractOutput{}, validationErrorf("owner not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Owner); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 	}
 
 	if in.TokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.TokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	if in.Payer == "" {
-		return types.ContractOutput{}, fmt.Errorf("payer not set")
+		return types.ContractOutput{}, validationErrorf("payer not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Payer); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid payer address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid payer address: %w", err)
 	}
 
 	if in.Payee == "" {
-		return types.ContractOutput{}, fmt.Errorf("payee not set")
+		return types.ContractOutput{}, validationErrorf("payee not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Payee); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid payee address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid payee address: %w", err)
 	}
 
 	if in.Payer == in.Payee {
-		return types.ContractOutput{}, fmt.Errorf("payee and payer cannot be the same: %s - %s", in.Payee, in.Payer)
+		return types.ContractOutput{}, validationErrorf("payee and payer cannot be the same: %s - %s", in.Payee, in.Payer)
 	}
 
 	if in.OrderId == "" {
-		return types.ContractOutput{}, fmt.Errorf("order_id not set")
+		return types.ContractOutput{}, validationErrorf("order_id not set")
 	}
 	if in.Amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 	if in.ExpiredAt.IsZero() {
-		return types.ContractOutput{}, fmt.Errorf("expired_at not set")
+		return types.ContractOutput{}, validationErrorf("expired_at not set")
 	}
 
 	to := in.Address
@@ -87,64 +89,64 @@ func (c *networkClient) CreatePayment(in inputs.InputCreate) (types.ContractOutp
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // DirectPay creates + authorizes + captures in one step.
 func (c *networkClient) DirectPay(in inputs.InputDirectPay) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
 	if in.Owner == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner not set")
+		return types.ContractOutput{}, validationErrorf("owner not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Owner); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 	}
 
 	if in.TokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.TokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	if in.Payer == "" {
-		return types.ContractOutput{}, fmt.Errorf("payer not set")
+		return types.ContractOutput{}, validationErrorf("payer not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Payer); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid payer address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid payer address: %w", err)
 	}
 
 	if in.Payee == "" {
-		return types.ContractOutput{}, fmt.Errorf("payee not set")
+		return types.ContractOutput{}, validationErrorf("payee not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Payee); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid payee address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid payee address: %w", err)
 	}
 
 	if in.Payer == in.Payee {
-		return types.ContractOutput{}, fmt.Errorf("payee and payer cannot be the same: %s - %s", in.Payee, in.Payer)
+		return types.ContractOutput{}, validationErrorf("payee and payer cannot be the same: %s - %s", in.Payee, in.Payer)
 	}
 
 	if in.OrderId == "" {
-		return types.ContractOutput{}, fmt.Errorf("order_id not set")
+		return types.ContractOutput{}, validationErrorf("order_id not set")
 	}
 	if in.Amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 	if in.ExpiredAt.IsZero() {
-		return types.ContractOutput{}, fmt.Errorf("expired_at not set")
+		return types.ContractOutput{}, validationErrorf("expired_at not set")
 	}
 
 	to := in.Address
@@ -167,21 +169,21 @@ func (c *networkClient) DirectPay(in inputs.InputDirectPay) (types.ContractOutpu
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // AuthorizePayment places a hold on funds.
 func (c *networkClient) AuthorizePayment(in inputs.InputAuthorize) (types.ContractOutput, error) {
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := in.Address
@@ -196,21 +198,21 @@ func (c *networkClient) AuthorizePayment(in inputs.InputAuthorize) (types.Contra
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // CapturePayment settles funds.
 func (c *networkClient) CapturePayment(in inputs.InputCapture) (types.ContractOutput, error) {
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := in.Address
@@ -225,24 +227,24 @@ func (c *networkClient) CapturePayment(in inputs.InputCapture) (types.ContractOu
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // RefundPayment returns funds from payee back to payer.
 func (c *networkClient) RefundPayment(in inputs.InputRefund) (types.ContractOutput, error) {
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if in.Amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := in.Address
@@ -258,21 +260,21 @@ func (c *networkClient) RefundPayment(in inputs.InputRefund) (types.ContractOutp
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // VoidPayment releases an authorization hold.
 func (c *networkClient) VoidPayment(in inputs.InputVoidPayment) (types.ContractOutput, error) {
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := in.Address
@@ -287,24 +289,24 @@ func (c *networkClient) VoidPayment(in inputs.InputVoidPayment) (types.ContractO
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // PausePayment toggles paused=true.
 func (c *networkClient) PausePayment(in inputs.InputPause) (types.ContractOutput, error) {
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if !in.Paused {
-		return types.ContractOutput{}, fmt.Errorf("paused must be true: Pause: %t", in.Paused)
+		return types.ContractOutput{}, validationErrorf("paused must be true: Pause: %t", in.Paused)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := in.Address
@@ -320,24 +322,24 @@ func (c *networkClient) PausePayment(in inputs.InputPause) (types.ContractOutput
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // UnpausePayment toggles paused=false.
 func (c *networkClient) UnpausePayment(in inputs.InputPause) (types.ContractOutput, error) {
 	if in.Address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(in.Address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if in.Paused {
-		return types.ContractOutput{}, fmt.Errorf("paused must be false: Pause: %t", in.Paused)
+		return types.ContractOutput{}, validationErrorf("paused must be false: Pause: %t", in.Paused)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := in.Address
@@ -353,22 +355,22 @@ func (c *networkClient) UnpausePayment(in inputs.InputPause) (types.ContractOutp
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // GetPayment reads a single payment state.
 func (c *networkClient) GetPayment(address string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("payment address must be set")
+		return types.ContractOutput{}, validationErrorf("payment address must be set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid payment address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid payment address: %w", err)
 	}
 
 	method := paymentV1.METHOD_GET_PAYMENT
@@ -377,32 +379,32 @@ func (c *networkClient) GetPayment(address string) (types.ContractOutput, error)
 
 // ListPayments queries payments with filters + pagination.
 func (c *networkClient) ListPayments(in inputs.InputList) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if in.TokenAddress != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(in.TokenAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 		}
 	}
 	if in.Payer != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(in.Payer); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid payer address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid payer address: %w", err)
 		}
 	}
 	if in.Payee != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(in.Payee); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid payee address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid payee address: %w", err)
 		}
 	}
 	if in.Page < 1 {
-		return types.ContractOutput{}, fmt.Errorf("page must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("page must be greater than 0")
 	}
 	if in.Limit < 1 {
-		return types.ContractOutput{}, fmt.Errorf("limit must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("limit must be greater than 0")
 	}
 
 	method := paymentV1.METHOD_LIST_PAYMENTS
@@ -420,3 +422,20 @@ func (c *networkClient) ListPayments(in inputs.InputList) (types.ContractOutput,
 
 	return c.GetState("", method, data)
 }
+
+// DecodePayment returns the payment logged first by CreatePayment, DirectPay,
+// AuthorizePayment, CapturePayment, RefundPayment, VoidPayment, PausePayment
+// or UnpausePayment.
+func DecodePayment(out types.ContractOutput, err error) (domain.Payment, error) {
+	return decodeEventResult[domain.Payment](out, err, "")
+}
+
+// DecodePaymentState returns the payment read by GetPayment.
+func DecodePaymentState(out types.ContractOutput, err error) (models.PaymentStateModel, error) {
+	return decodeStateResult[models.PaymentStateModel](out, err)
+}
+
+// DecodePayments returns the payments read by ListPayments.
+func DecodePayments(out types.ContractOutput, err error) ([]models.PaymentStateModel, error) {
+	return decodeStateResult[[]models.PaymentStateModel](out, err)
+}
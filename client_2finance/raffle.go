@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"gitlab.com/2finance/2finance-network/blockchain/contract/raffleV1"
+	raffleV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/raffleV1/domain"
+	raffleV1Models "gitlab.com/2finance/2finance-network/blockchain/contract/raffleV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/types"
@@ -20,46 +22,46 @@ func (c *networkClient) AddRaffle(
 	seedCommitHex string,
 	metadata map[string]string,
 ) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid raffle address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid raffle address: %w", err)
 	}
 	if owner == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner not set")
+		return types.ContractOutput{}, validationErrorf("owner not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(owner); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 	if ticketPrice == "" {
-		return types.ContractOutput{}, fmt.Errorf("ticket_price not set")
+		return types.ContractOutput{}, validationErrorf("ticket_price not set")
 	}
 	if maxEntries <= 0 {
-		return types.ContractOutput{}, fmt.Errorf("max_entries must be > 0")
+		return types.ContractOutput{}, validationErrorf("max_entries must be > 0")
 	}
 	if maxEntriesPerUser <= 0 {
-		return types.ContractOutput{}, fmt.Errorf("max_entries_per_user must be > 0")
+		return types.ContractOutput{}, validationErrorf("max_entries_per_user must be > 0")
 	}
 	if maxEntriesPerUser > maxEntries {
-		return types.ContractOutput{}, fmt.Errorf("max_entries_per_user cannot exceed max_entries")
+		return types.ContractOutput{}, validationErrorf("max_entries_per_user cannot exceed max_entries")
 	}
 	if startAt.IsZero() {
-		return types.ContractOutput{}, fmt.Errorf("start_at not set")
+		return types.ContractOutput{}, validationErrorf("start_at not set")
 	}
 	if expiredAt.IsZero() {
-		return types.ContractOutput{}, fmt.Errorf("expired_at not set")
+		return types.ContractOutput{}, validationErrorf("expired_at not set")
 	}
 
 	to := address
@@ -85,7 +87,7 @@ func (c *networkClient) AddRaffle(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // UpdateRaffle updates mutable fields of an existing raffle.
@@ -97,31 +99,31 @@ func (c *networkClient) UpdateRaffle(
 	metadata map[string]string,
 ) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if tokenAddress != "" { // optional change
 		if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 		}
 	}
 	if ticketPrice == "" && maxEntries == 0 && maxEntriesPerUser == 0 && startAt == nil && expiredAt == nil && seedCommitHex == "" && len(metadata) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("no fields to update")
+		return types.ContractOutput{}, validationErrorf("no fields to update")
 	}
 	if maxEntries < 0 || maxEntriesPerUser < 0 {
-		return types.ContractOutput{}, fmt.Errorf("max entries must be >= 0")
+		return types.ContractOutput{}, validationErrorf("max entries must be >= 0")
 	}
 	if maxEntries > 0 && maxEntriesPerUser > maxEntries {
-		return types.ContractOutput{}, fmt.Errorf("max_entries_per_user cannot exceed max_entries")
+		return types.ContractOutput{}, validationErrorf("max_entries_per_user cannot exceed max_entries")
 	}
 
 	to := address
@@ -148,27 +150,27 @@ func (c *networkClient) UpdateRaffle(
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // PauseRaffle sets paused=true. OnlyOwner.
 func (c *networkClient) PauseRaffle(address string, paused bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if !paused {
-		return types.ContractOutput{}, fmt.Errorf("paused must be true: Pause: %t", paused)
+		return types.ContractOutput{}, validationErrorf("paused must be true: Pause: %t", paused)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -179,27 +181,27 @@ func (c *networkClient) PauseRaffle(address string, paused bool) (types.Contract
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // UnpauseRaffle sets paused=false. OnlyOwner.
 func (c *networkClient) UnpauseRaffle(address string, paused bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if paused {
-		return types.ContractOutput{}, fmt.Errorf("paused must be false: Pause: %t", paused)
+		return types.ContractOutput{}, validationErrorf("paused must be false: Pause: %t", paused)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -210,47 +212,47 @@ func (c *networkClient) UnpauseRaffle(address string, paused bool) (types.Contra
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 func (c *networkClient) EnterRaffle(address string, tickets int, payTokenAddress, tokenType, uuid string) (types.ContractOutput, error) {
 	// Pre-check client state
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 
 	// Validate inputs (server/domain will also validate)
-	if err := keys.ValidateEDDSAPublicKeyHex(c.walletManager.GetPublicKey()); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid client public key: %w", err)
+	if err := keys.ValidateEDDSAPublicKeyHex(c.signerPublicKey()); err != nil {
+		return types.ContractOutput{}, validationErrorf("invalid client public key: %w", err)
 	}
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address is required")
+		return types.ContractOutput{}, validationErrorf("address is required")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid raffle address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid raffle address: %w", err)
 	}
 	if tickets <= 0 {
-		return types.ContractOutput{}, fmt.Errorf("tickets must be > 0")
+		return types.ContractOutput{}, validationErrorf("tickets must be > 0")
 	}
 	if payTokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("pay_token_address is required")
+		return types.ContractOutput{}, validationErrorf("pay_token_address is required")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(payTokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid pay_token_address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid pay_token_address: %w", err)
 	}
 	if tokenType == "" {
-		return types.ContractOutput{}, fmt.Errorf("tokenType not set")
+		return types.ContractOutput{}, validationErrorf("tokenType not set")
 	}
 	if tokenType == domain.NON_FUNGIBLE {
 		if uuid == "" {
-			return types.ContractOutput{}, fmt.Errorf("uuid must be set for non-fungible tokens")
+			return types.ContractOutput{}, validationErrorf("uuid must be set for non-fungible tokens")
 		}
 	}
 	// Exact payload fields expected by the refactored EnterRaffle handler
 	data := map[string]interface{}{
 		"address":           address,
-		"entrant":           c.walletManager.GetPublicKey(),
+		"entrant":           c.signerPublicKey(),
 		"tickets":           tickets,
 		"pay_token_address": payTokenAddress,
 		"token_type":        tokenType,
@@ -262,7 +264,7 @@ func (c *networkClient) EnterRaffle(address string, tickets int, payTokenAddress
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	return c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		address,
 		raffleV1.METHOD_ENTER_RAFFLE, // method constant
@@ -275,21 +277,21 @@ func (c *networkClient) EnterRaffle(address string, tickets int, payTokenAddress
 // DrawRaffle reveals the seed and draws winners (commit-reveal). OnlyOwner/Moderator.
 func (c *networkClient) DrawRaffle(address, revealSeed string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if revealSeed == "" {
-		return types.ContractOutput{}, fmt.Errorf("reveal_seed not set")
+		return types.ContractOutput{}, validationErrorf("reveal_seed not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -304,27 +306,27 @@ func (c *networkClient) DrawRaffle(address, revealSeed string) (types.ContractOu
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // ClaimRaffle allows a winner to claim their prize.
 func (c *networkClient) ClaimRaffle(address, prizeUUID string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if prizeUUID == "" {
-		return types.ContractOutput{}, fmt.Errorf("prizeUUID not set")
+		return types.ContractOutput{}, validationErrorf("prizeUUID not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -335,42 +337,42 @@ func (c *networkClient) ClaimRaffle(address, prizeUUID string) (types.ContractOu
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 
 }
 
 // WithdrawRaffle withdraws unused/prize funds from the raffle pool.
 func (c *networkClient) WithdrawRaffle(address, tokenAddress, amount, tokenType, uuid string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 	if tokenType == "" {
-		return types.ContractOutput{}, fmt.Errorf("tokenType not set")
+		return types.ContractOutput{}, validationErrorf("tokenType not set")
 	}
 	if tokenType == domain.NON_FUNGIBLE {
 		if uuid == "" {
-			return types.ContractOutput{}, fmt.Errorf("uuid must be set for non-fungible tokens")
+			return types.ContractOutput{}, validationErrorf("uuid must be set for non-fungible tokens")
 		}
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -381,32 +383,32 @@ func (c *networkClient) WithdrawRaffle(address, tokenAddress, amount, tokenType,
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 func (c *networkClient) AddRafflePrize(raffleAddress string, tokenAddress string, amount string, uuidNFTs []string) (types.ContractOutput, error) {
 	if raffleAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("raffle address not set")
+		return types.ContractOutput{}, validationErrorf("raffle address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(raffleAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid raffle address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid raffle address: %w", err)
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 	if amount == "" && len(uuidNFTs) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("amount not set or uuidNFTs not set")
+		return types.ContractOutput{}, validationErrorf("amount not set or uuidNFTs not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := raffleAddress
@@ -417,26 +419,26 @@ func (c *networkClient) AddRafflePrize(raffleAddress string, tokenAddress string
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 func (c *networkClient) RemoveRafflePrize(raffleAddress string, uuid string) (types.ContractOutput, error) {
 	if raffleAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("raffle address not set")
+		return types.ContractOutput{}, validationErrorf("raffle address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(raffleAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid raffle address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid raffle address: %w", err)
 	}
 	if uuid == "" {
-		return types.ContractOutput{}, fmt.Errorf("uuid not set")
+		return types.ContractOutput{}, validationErrorf("uuid not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := raffleAddress
@@ -447,23 +449,23 @@ func (c *networkClient) RemoveRafflePrize(raffleAddress string, uuid string) (ty
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // GetRaffle reads a single raffle state.
 func (c *networkClient) GetRaffle(address string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("raffle address must be set")
+		return types.ContractOutput{}, validationErrorf("raffle address must be set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid raffle address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid raffle address: %w", err)
 	}
 
 	method := raffleV1.METHOD_GET_RAFFLE
@@ -473,29 +475,29 @@ func (c *networkClient) GetRaffle(address string) (types.ContractOutput, error)
 
 // ListRaffles queries raffles with filters + pagination.
 func (c *networkClient) ListRaffles(owner, tokenAddress string, paused *bool, activeOnly *bool, page, limit int, asc bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if owner != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(owner); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 		}
 	}
 	if tokenAddress != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 		}
 	}
 	if page < 1 {
-		return types.ContractOutput{}, fmt.Errorf("page must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("page must be greater than 0")
 	}
 	if limit < 1 {
-		return types.ContractOutput{}, fmt.Errorf("limit must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("limit must be greater than 0")
 	}
 
 	method := raffleV1.METHOD_LIST_RAFFLES
@@ -517,24 +519,24 @@ func (c *networkClient) ListRaffles(owner, tokenAddress string, paused *bool, ac
 }
 
 func (c *networkClient) ListPrizes(raffleAddress string, page, limit int, asc bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if raffleAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("raffle address must be set")
+		return types.ContractOutput{}, validationErrorf("raffle address must be set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(raffleAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid raffle address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid raffle address: %w", err)
 	}
 	if page < 1 {
-		return types.ContractOutput{}, fmt.Errorf("page must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("page must be greater than 0")
 	}
 	if limit < 1 {
-		return types.ContractOutput{}, fmt.Errorf("limit must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("limit must be greater than 0")
 	}
 
 	method := raffleV1.METHOD_LIST_PRIZES
@@ -550,15 +552,15 @@ func (c *networkClient) ListPrizes(raffleAddress string, page, limit int, asc bo
 }
 
 func (c *networkClient) GetPrize(address string, prizeUUID string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if prizeUUID == "" {
-		return types.ContractOutput{}, fmt.Errorf("prize UUID must be set")
+		return types.ContractOutput{}, validationErrorf("prize UUID must be set")
 	}
 
 	method := raffleV1.METHOD_GET_PRIZE
@@ -570,3 +572,42 @@ func (c *networkClient) GetPrize(address string, prizeUUID string) (types.Contra
 
 	return c.GetState(address, method, data)
 }
+
+// DecodeRaffle returns the raffle logged by AddRaffle, UpdateRaffle,
+// PauseRaffle or UnpauseRaffle.
+func DecodeRaffle(out types.ContractOutput, err error) (raffleV1Domain.Raffle, error) {
+	return decodeEventResult[raffleV1Domain.Raffle](out, err, "")
+}
+
+// DecodeRaffleEntry returns the entry recorded by EnterRaffle.
+func DecodeRaffleEntry(out types.ContractOutput, err error) (raffleV1Domain.Entry, error) {
+	return decodeEventResult[raffleV1Domain.Entry](out, err, raffleV1Domain.RAFFLE_ENTERED_LOG)
+}
+
+// DecodeRaffleDraw returns the draw performed by DrawRaffle, winners included.
+func DecodeRaffleDraw(out types.ContractOutput, err error) (raffleV1Domain.Draw, error) {
+	return decodeEventResult[raffleV1Domain.Draw](out, err, raffleV1Domain.RAFFLE_DRAWN_LOG)
+}
+
+// DecodeRaffleClaim returns the prize claim performed by ClaimRaffle.
+func DecodeRaffleClaim(out types.ContractOutput, err error) (raffleV1Domain.Claim, error) {
+	return decodeEventResult[raffleV1Domain.Claim](out, err, raffleV1Domain.RAFFLE_CLAIMED_LOG)
+}
+
+// DecodeRafflePrizes returns the prizes added by AddRafflePrize.
+func DecodeRafflePrizes(out types.ContractOutput, err error) ([]raffleV1Domain.RafflePrize, error) {
+	if err != nil {
+		return nil, err
+	}
+	return DecodeEvents[raffleV1Domain.RafflePrize](out, raffleV1Domain.RAFFLE_ADDED_PRIZES_LOG)
+}
+
+// DecodeRaffleState returns the raffle read by GetRaffle.
+func DecodeRaffleState(out types.ContractOutput, err error) (raffleV1Models.RaffleStateModel, error) {
+	return decodeStateResult[raffleV1Models.RaffleStateModel](out, err)
+}
+
+// DecodeRafflePrizeStates returns the prizes read by ListPrizes.
+func DecodeRafflePrizeStates(out types.ContractOutput, err error) ([]raffleV1Models.RafflePrizeModel, error) {
+	return decodeStateResult[[]raffleV1Models.RafflePrizeModel](out, err)
+}
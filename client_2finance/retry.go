@@ -0,0 +1,191 @@
+package client_2finance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	blockchainLog "gitlab.com/2finance/2finance-network/blockchain/log"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+	"gitlab.com/2finance/2finance-network/blockchain/virtualmachine"
+)
+
+// RetryPolicy controls how SignAndSendTransaction retries a transaction whose
+// response timed out. Retries re-send the same signed transaction, so its
+// uuid7 and hash never change, and each retry first asks the node whether an
+// earlier attempt was already committed.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a transaction may be sent,
+	// including the first one. 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry. Each later wait is
+	// Multiplier times the previous one, capped at MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+
+	// Jitter is the fraction, between 0 and 1, of each wait that is
+	// randomized so clients do not retry in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy sends a transaction up to three times, waiting about
+// half a second and then a second between attempts.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// noRetryPolicy sends every transaction once.
+var noRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// committedLogsPageSize is the page size used to read the logs of a
+// committed transaction.
+const committedLogsPageSize = 50
+
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts < 1 {
+		return validationErrorf("retry max attempts must be at least 1: %d", p.MaxAttempts)
+	}
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		return validationErrorf("retry backoff cannot be negative")
+	}
+	if p.MaxBackoff > 0 && p.MaxBackoff < p.InitialBackoff {
+		return validationErrorf("retry max backoff %s is shorter than initial backoff %s", p.MaxBackoff, p.InitialBackoff)
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return validationErrorf("retry multiplier must be at least 1: %v", p.Multiplier)
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return validationErrorf("retry jitter must be between 0 and 1: %v", p.Jitter)
+	}
+	return nil
+}
+
+// backoff returns the wait before retry number retry, counting from 1.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+
+	wait := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		wait *= multiplier
+		if p.MaxBackoff > 0 && wait >= float64(p.MaxBackoff) {
+			wait = float64(p.MaxBackoff)
+			break
+		}
+	}
+
+	if p.Jitter > 0 {
+		wait += wait * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(wait)
+}
+
+// sendSignedTransaction sends tx, re-sending it under the client's retry
+// policy when the response times out, and decodes the contract output. When a
+// retry finds that an earlier attempt was committed even though its response
+// was lost, the output is rebuilt from the transaction's logs.
+func (c *networkClient) sendSignedTransaction(ctx context.Context, tx *transaction.Transaction) (types.ContractOutput, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	policy := c.retryPolicy
+	if policy.MaxAttempts < 1 {
+		policy = noRetryPolicy
+	}
+
+	for attempt := 1; ; attempt++ {
+		outputBytes, err := c.SendTransactionContext(ctx, virtualmachine.REQUEST_METHOD_SEND, tx, c.replyTo)
+		if err == nil {
+			var contractOutput types.ContractOutput
+			if err := json.Unmarshal(outputBytes, &contractOutput); err != nil {
+				return types.ContractOutput{}, fmt.Errorf("failed to unmarshal contract output: %w", err)
+			}
+			return contractOutput, nil
+		}
+		if attempt >= policy.MaxAttempts || !errors.Is(err, ErrTimeout) || ctx.Err() != nil {
+			return types.ContractOutput{}, err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return types.ContractOutput{}, err
+		case <-timer.C:
+		}
+
+		// A failed lookup leaves the outcome unknown; re-sending is still
+		// safe because the node rejects a hash it has already committed.
+		if committed, lookupErr := c.transactionCommitted(ctx, tx.Hash); lookupErr == nil && committed {
+			return c.committedOutput(ctx, tx.Hash)
+		}
+	}
+}
+
+// committedOutput rebuilds the contract output of the committed transaction
+// with hash from its logs, for when the node's response to it was lost. The
+// logs of delegated calls are listed with the others, and States are empty:
+// read them with the contract's getters.
+func (c *networkClient) committedOutput(ctx context.Context, hash string) (types.ContractOutput, error) {
+	var out types.ContractOutput
+	for page := 1; ; page++ {
+		logsBytes, err := c.SendTransactionContext(ctx, virtualmachine.REQUEST_METHOD_GET_LOGS, blockchainLog.LogParams{
+			TransactionHash: hash,
+			Page:            page,
+			Limit:           committedLogsPageSize,
+			Ascending:       true,
+		}, c.replyTo)
+		if err != nil {
+			return types.ContractOutput{}, fmt.Errorf("transaction %s was committed but its logs could not be read: %w", hash, err)
+		}
+
+		// A contract output holds its logs as the raw JSON the node lists.
+		var logs []json.RawMessage
+		if err := json.Unmarshal(logsBytes, &logs); err != nil {
+			return types.ContractOutput{}, fmt.Errorf("transaction %s was committed but its logs could not be read: failed to unmarshal logs: %w", hash, err)
+		}
+		out.Logs = append(out.Logs, logs...)
+
+		if len(logs) < committedLogsPageSize {
+			return out, nil
+		}
+	}
+}
+
+// transactionCommitted reports whether the node has a transaction with hash.
+func (c *networkClient) transactionCommitted(ctx context.Context, hash string) (bool, error) {
+	transactionBytes, err := c.SendTransactionContext(ctx, virtualmachine.REQUEST_METHOD_GET_TRANSACTIONS, transaction.TransactionInput{
+		Hash:  hash,
+		Page:  1,
+		Limit: 1,
+	}, c.replyTo)
+	if err != nil {
+		return false, err
+	}
+
+	var transactions []transaction.Transaction
+	if err := json.Unmarshal(transactionBytes, &transactions); err != nil {
+		return false, fmt.Errorf("failed to unmarshal transactions: %w", err)
+	}
+
+	for _, tx := range transactions {
+		if tx.Hash == hash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
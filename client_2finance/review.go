@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"gitlab.com/2finance/2finance-network/blockchain/contract/reviewV1"
+	reviewV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/reviewV1/domain"
+	reviewV1Models "gitlab.com/2finance/2finance-network/blockchain/contract/reviewV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/types"
 	"gitlab.com/2finance/2finance-network/blockchain/utils"
@@ -20,41 +22,41 @@ func (c *networkClient) AddReview(
 	startAt, expiredAt time.Time,
 	hidden bool,
 ) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid review address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid review address: %w", err)
 	}
 
 	if reviewer == "" {
-		return types.ContractOutput{}, fmt.Errorf("reviewer not set")
+		return types.ContractOutput{}, validationErrorf("reviewer not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(reviewer); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid reviewer address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid reviewer address: %w", err)
 	}
 	if reviewee == "" {
-		return types.ContractOutput{}, fmt.Errorf("reviewee not set")
+		return types.ContractOutput{}, validationErrorf("reviewee not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(reviewee); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid reviewee address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid reviewee address: %w", err)
 	}
 	if subjectType == "" {
-		return types.ContractOutput{}, fmt.Errorf("subject_type not set")
+		return types.ContractOutput{}, validationErrorf("subject_type not set")
 	}
 	if subjectID == "" {
-		return types.ContractOutput{}, fmt.Errorf("subject_id not set")
+		return types.ContractOutput{}, validationErrorf("subject_id not set")
 	}
 	if rating < 1 || rating > 5 {
-		return types.ContractOutput{}, fmt.Errorf("rating must be between 1 and 5")
+		return types.ContractOutput{}, validationErrorf("rating must be between 1 and 5")
 	}
 	if startAt.IsZero() {
-		return types.ContractOutput{}, fmt.Errorf("start_at not set")
+		return types.ContractOutput{}, validationErrorf("start_at not set")
 	}
 	if expiredAt.IsZero() {
-		return types.ContractOutput{}, fmt.Errorf("expired_at not set")
+		return types.ContractOutput{}, validationErrorf("expired_at not set")
 	}
 
 	to := address
@@ -79,7 +81,7 @@ func (c *networkClient) AddReview(
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // UpdateReview modifies fields of an existing review.
@@ -92,26 +94,26 @@ func (c *networkClient) UpdateReview(
 	startAt, expiredAt *time.Time,
 ) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if subjectType == "" {
-		return types.ContractOutput{}, fmt.Errorf("subject_type not set")
+		return types.ContractOutput{}, validationErrorf("subject_type not set")
 	}
 	if subjectID == "" {
-		return types.ContractOutput{}, fmt.Errorf("subject_id not set")
+		return types.ContractOutput{}, validationErrorf("subject_id not set")
 	}
 	if rating != 0 && (rating < 1 || rating > 5) { // allow 0 to mean "no change"
-		return types.ContractOutput{}, fmt.Errorf("rating must be between 1 and 5")
+		return types.ContractOutput{}, validationErrorf("rating must be between 1 and 5")
 	}
 
 	to := address
@@ -137,22 +139,22 @@ func (c *networkClient) UpdateReview(
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // HideReview toggles the hidden state. OnlyOwner.
 func (c *networkClient) HideReview(address string, hidden bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -166,28 +168,28 @@ func (c *networkClient) HideReview(address string, hidden bool) (types.ContractO
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // VoteHelpful registers an up/down helpful vote for a review.
 func (c *networkClient) VoteHelpful(address, voter string, isHelpful bool) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if voter == "" {
-		return types.ContractOutput{}, fmt.Errorf("voter not set")
+		return types.ContractOutput{}, validationErrorf("voter not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(voter); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid voter address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid voter address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -202,31 +204,31 @@ func (c *networkClient) VoteHelpful(address, voter string, isHelpful bool) (type
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // ReportReview flags a review with a reason string by a reporter.
 func (c *networkClient) ReportReview(address, reporter, reason string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if reporter == "" {
-		return types.ContractOutput{}, fmt.Errorf("reporter not set")
+		return types.ContractOutput{}, validationErrorf("reporter not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(reporter); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid reporter address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid reporter address: %w", err)
 	}
 	if reason == "" {
-		return types.ContractOutput{}, fmt.Errorf("reason not set")
+		return types.ContractOutput{}, validationErrorf("reason not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -241,25 +243,25 @@ func (c *networkClient) ReportReview(address, reporter, reason string) (types.Co
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // ModerateReview applies a moderation action (e.g., approve/reject/remove) with an optional note. OnlyModerator/Owner per contract rules.
 func (c *networkClient) ModerateReview(address, action, note string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("address not set")
+		return types.ContractOutput{}, validationErrorf("address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid address: %w", err)
 	}
 	if action == "" {
-		return types.ContractOutput{}, fmt.Errorf("action not set")
+		return types.ContractOutput{}, validationErrorf("action not set")
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -274,21 +276,21 @@ func (c *networkClient) ModerateReview(address, action, note string) (types.Cont
 	if err != nil {
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
-	return c.SignAndSendTransaction(c.chainId, from, to, method, data, version, uuid7)
+	return c.SignAndSendTransaction(c.GetChainID(), from, to, method, data, version, uuid7)
 }
 
 // GetReview retrieves a single review state.
 func (c *networkClient) GetReview(address string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("review address must be set")
+		return types.ContractOutput{}, validationErrorf("review address must be set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid review address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid review address: %w", err)
 	}
 
 	method := reviewV1.METHOD_GET_REVIEW
@@ -303,38 +305,38 @@ func (c *networkClient) ListReviews(
 	minRating, maxRating, page, limit int,
 	asc bool,
 ) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	// Optional address validations
 	if reviewer != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(reviewer); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid reviewer address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid reviewer address: %w", err)
 		}
 	}
 	if reviewee != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(reviewee); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid reviewee address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid reviewee address: %w", err)
 		}
 	}
 
 	if page < 1 {
-		return types.ContractOutput{}, fmt.Errorf("page must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("page must be greater than 0")
 	}
 	if limit < 1 {
-		return types.ContractOutput{}, fmt.Errorf("limit must be greater than 0")
+		return types.ContractOutput{}, validationErrorf("limit must be greater than 0")
 	}
 	if minRating < 0 || minRating > 5 {
-		return types.ContractOutput{}, fmt.Errorf("min_rating must be between 0 and 5")
+		return types.ContractOutput{}, validationErrorf("min_rating must be between 0 and 5")
 	}
 	if maxRating < 0 || maxRating > 5 {
-		return types.ContractOutput{}, fmt.Errorf("max_rating must be between 0 and 5")
+		return types.ContractOutput{}, validationErrorf("max_rating must be between 0 and 5")
 	}
 	if maxRating != 0 && minRating > maxRating {
-		return types.ContractOutput{}, fmt.Errorf("min_rating cannot be greater than max_rating")
+		return types.ContractOutput{}, validationErrorf("min_rating cannot be greater than max_rating")
 	}
 
 	method := reviewV1.METHOD_LIST_REVIEWS
@@ -356,3 +358,13 @@ func (c *networkClient) ListReviews(
 
 	return c.GetState("", method, data)
 }
+
+// DecodeReview returns the review logged by AddReview, UpdateReview or HideReview.
+func DecodeReview(out types.ContractOutput, err error) (reviewV1Domain.Review, error) {
+	return decodeEventResult[reviewV1Domain.Review](out, err, "")
+}
+
+// DecodeReviewState returns the review read by GetReview.
+func DecodeReviewState(out types.ContractOutput, err error) (reviewV1Models.ReviewStateModel, error) {
+	return decodeStateResult[reviewV1Models.ReviewStateModel](out, err)
+}
@@ -0,0 +1,137 @@
+package client_2finance
+
+import (
+	"context"
+
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+)
+
+// signerKey is the context key WithSigner stores the signer under.
+type signerKey struct{}
+
+// passwordKey is the context key WithPassword stores the password under.
+type passwordKey struct{}
+
+// WithSigner returns a copy of ctx that makes contract methods act for
+// signer: its public key is used as the sender and it signs the
+// transaction, in place of the client's default signer. Pass the context to
+// Client2FinanceNetwork.WithContext, or to a method taking a context:
+//
+//	out, err := c.WithContext(client_2finance.WithSigner(ctx, payer)).TransferToken(token, payee, "10", nil)
+//
+// Unlike SetWalletManager it does not change the client, so concurrent calls
+// can each use their own signer on one shared client.
+func WithSigner(ctx context.Context, signer wallet_manager.Signer) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, signerKey{}, signer)
+}
+
+// SignerFromContext returns the signer set on ctx by WithSigner.
+func SignerFromContext(ctx context.Context) (wallet_manager.Signer, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	signer, ok := ctx.Value(signerKey{}).(wallet_manager.Signer)
+	return signer, ok && signer != nil
+}
+
+// signerFor returns the signer set on ctx, then the one set on the context
+// the client is bound to, and falls back to the client's default signer.
+func (c *networkClient) signerFor(ctx context.Context) wallet_manager.Signer {
+	if signer, ok := SignerFromContext(ctx); ok {
+		return signer
+	}
+	return c.signer()
+}
+
+// signer returns the wallet that signs the calls made through c.
+func (c *networkClient) signer() wallet_manager.Signer {
+	if signer, ok := SignerFromContext(c.context()); ok {
+		return signer
+	}
+	c.settings.mu.RLock()
+	defer c.settings.mu.RUnlock()
+
+	return c.settings.signer
+}
+
+// signerPublicKey returns the sender address of the calls made through c, or
+// "" when there is no signer, which the address validation then rejects.
+func (c *networkClient) signerPublicKey() string {
+	signer := c.signer()
+	if signer == nil {
+		return ""
+	}
+	return signer.GetPublicKey()
+}
+
+// WithPassword returns a copy of ctx carrying the wallet password for the
+// calls made with it. Methods the wallet marks with SetPasswordRequired fail
+// with ErrPasswordRequired unless they are called this way. A locked wallet
+// signs these calls with the password without being unlocked. Signers that do
+// not take passwords ignore it.
+func WithPassword(ctx context.Context, password string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, passwordKey{}, password)
+}
+
+// passwordFor returns the password set on ctx or on the context the client is
+// bound to.
+func (c *networkClient) passwordFor(ctx context.Context) (string, bool) {
+	for _, ctx := range []context.Context{ctx, c.context()} {
+		if ctx == nil {
+			continue
+		}
+		if password, ok := ctx.Value(passwordKey{}).(string); ok && password != "" {
+			return password, true
+		}
+	}
+	return "", false
+}
+
+// signTransaction signs with signer, giving it the call's password when it
+// takes one.
+func (c *networkClient) signTransaction(
+	ctx context.Context,
+	signer wallet_manager.Signer,
+	chainId uint8,
+	from, to, method string,
+	data map[string]interface{},
+	version uint8,
+	uuid7 string,
+) (*transaction.Transaction, error) {
+	password, _ := c.passwordFor(ctx)
+	return signWithPassword(signer, password, chainId, from, to, method, data, version, uuid7)
+}
+
+// signWithPassword signs with signer, giving it password when it is set and
+// the signer takes one.
+func signWithPassword(
+	signer wallet_manager.Signer,
+	password string,
+	chainId uint8,
+	from, to, method string,
+	data map[string]interface{},
+	version uint8,
+	uuid7 string,
+) (*transaction.Transaction, error) {
+	passwordSigner, takesPassword := signer.(wallet_manager.PasswordSigner)
+	if password != "" && takesPassword {
+		return passwordSigner.SignTransactionWithPassword(password, chainId, from, to, method, data, version, uuid7)
+	}
+
+	if takesPassword && passwordSigner.RequiresPassword(method) {
+		return nil, &wallet_manager.PasswordRequiredError{Method: method}
+	}
+
+	if !signer.IsUnlocked() {
+		return nil, &wallet_manager.WalletLockedError{Owner: signer.GetPublicKey(), Method: method}
+	}
+
+	return signer.SignTransaction(chainId, from, to, method, data, version, uuid7)
+}
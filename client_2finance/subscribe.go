@@ -0,0 +1,291 @@
+package client_2finance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/block"
+	blockchainLog "gitlab.com/2finance/2finance-network/blockchain/log"
+)
+
+// ErrSubscriptionFailed is reported through WithSubscribeErrors when a
+// subscription stops because its polls keep failing.
+var ErrSubscriptionFailed = errors.New("subscription stopped")
+
+// subscribeMaxFailures is how many polls in a row may fail before a
+// subscription gives up and closes its channel.
+const subscribeMaxFailures = 10
+
+// SubscribeOption configures SubscribeLogs and SubscribeBlocks.
+type SubscribeOption func(*subscribeOptions) error
+
+type subscribeOptions struct {
+	pollInterval time.Duration
+	onError      func(error)
+}
+
+// WithSubscribePollInterval sets how often SubscribeLogs and SubscribeBlocks
+// ask the node for new blocks. The default is one second.
+func WithSubscribePollInterval(interval time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) error {
+		if interval <= 0 {
+			return validationErrorf("poll interval must be greater than 0: %s", interval)
+		}
+		o.pollInterval = interval
+		return nil
+	}
+}
+
+// WithSubscribeErrors calls onError with the error of every failed poll, from
+// the goroutine of the subscription. The last call, once polls failed
+// subscribeMaxFailures times in a row, wraps ErrSubscriptionFailed.
+func WithSubscribeErrors(onError func(error)) SubscribeOption {
+	return func(o *subscribeOptions) error {
+		if onError == nil {
+			return validationErrorf("subscription error callback is required")
+		}
+		o.onError = onError
+		return nil
+	}
+}
+
+// SubscribeLogs streams the logs of the blocks committed after the call that
+// match filter. LogType, LogIndex, TransactionHash, ContractAddress and Event
+// filter the same way they do for ListLogs; unset fields match every log, and
+// Event matches logs whose event has all the given fields with equal values.
+// Page, Limit and Ascending are ignored.
+//
+// The node does not push events, so the stream is built by polling ListBlocks
+// and then ListLogs for the transactions of each new block. Logs arrive block
+// by block, in the order they were emitted. Polling waits while the caller is
+// busy, so none are lost to a slow reader, and a failed request is retried at
+// the next poll; WithSubscribeErrors reports the failures. The returned
+// channel is closed once ctx is done, or after 10 failed polls in a row.
+func (c *networkClient) SubscribeLogs(ctx context.Context, filter blockchainLog.LogParams, opts ...SubscribeOption) (<-chan blockchainLog.Log, error) {
+	return subscribe(ctx, c, opts, func(p *blockPoller, b block.Block) ([]blockchainLog.Log, error) {
+		return p.logs(b, filter)
+	})
+}
+
+// SubscribeBlocks streams the blocks committed after the call, oldest first,
+// by polling ListBlocks like SubscribeLogs. The returned channel is closed
+// once ctx is done, or after 10 failed polls in a row.
+func (c *networkClient) SubscribeBlocks(ctx context.Context, opts ...SubscribeOption) (<-chan block.Block, error) {
+	return subscribe(ctx, c, opts, func(_ *blockPoller, b block.Block) ([]block.Block, error) {
+		return []block.Block{b}, nil
+	})
+}
+
+// subscribe polls the node for new blocks and sends on the returned channel
+// the values events makes of each of them. A block is only passed once all
+// its values were sent, so that a failed request repeats nothing.
+func subscribe[T any](ctx context.Context, c *networkClient, opts []SubscribeOption, events func(*blockPoller, block.Block) ([]T, error)) (<-chan T, error) {
+	o := subscribeOptions{pollInterval: defaultPollInterval}
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return nil, err
+		}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	p := &blockPoller{client: c.withContext(ctx)}
+	if err := p.start(); err != nil {
+		return nil, err
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(o.pollInterval)
+		defer ticker.Stop()
+
+		failures := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			err := poll(ctx, p, out, events)
+			if err == nil {
+				failures = 0
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			failures++
+			if failures >= subscribeMaxFailures {
+				err = fmt.Errorf("%w after %d failed polls: %w", ErrSubscriptionFailed, failures, err)
+			}
+			if o.onError != nil {
+				o.onError(err)
+			}
+			if failures >= subscribeMaxFailures {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// poll sends the values of the blocks committed since the last poll.
+func poll[T any](ctx context.Context, p *blockPoller, out chan<- T, events func(*blockPoller, block.Block) ([]T, error)) error {
+	blocks, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	for _, b := range blocks {
+		values, err := events(p, b)
+		if err != nil {
+			return err
+		}
+		for _, v := range values {
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		p.last = b.Number
+	}
+
+	return nil
+}
+
+// blockPoller finds the blocks committed since the last one it passed.
+type blockPoller struct {
+	client *networkClient
+	last   uint64
+}
+
+// start makes the latest block the last one passed.
+func (p *blockPoller) start() error {
+	blocks, err := p.client.ListBlocks(0, time.Time{}, "", "", "", 1, 1, false)
+	if err != nil {
+		return err
+	}
+	if len(blocks) > 0 {
+		p.last = blocks[0].Number
+	}
+	return nil
+}
+
+// next returns the blocks newer than the last one passed, oldest first.
+func (p *blockPoller) next() ([]block.Block, error) {
+	var blocks []block.Block
+	for page := 1; ; page++ {
+		batch, err := p.client.ListBlocks(0, time.Time{}, "", "", "", page, waitPageSize, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range batch {
+			if b.Number <= p.last {
+				slices.Reverse(blocks)
+				return blocks, nil
+			}
+			blocks = append(blocks, b)
+		}
+
+		if len(batch) < waitPageSize {
+			slices.Reverse(blocks)
+			return blocks, nil
+		}
+	}
+}
+
+// logs returns the logs of the transactions of b that match filter.
+func (p *blockPoller) logs(b block.Block, filter blockchainLog.LogParams) ([]blockchainLog.Log, error) {
+	var logs []blockchainLog.Log
+	for _, tx := range b.Transactions {
+		if filter.TransactionHash != "" && filter.TransactionHash != tx.Hash {
+			continue
+		}
+
+		for page := 1; ; page++ {
+			batch, err := p.client.ListLogs(filter.LogType, filter.LogIndex, tx.Hash, filter.Event, filter.ContractAddress, page, waitPageSize, true)
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range batch {
+				if logMatches(filter, l) {
+					logs = append(logs, l)
+				}
+			}
+			if len(batch) < waitPageSize {
+				break
+			}
+		}
+	}
+	return logs, nil
+}
+
+func logMatches(filter blockchainLog.LogParams, l blockchainLog.Log) bool {
+	if len(filter.LogType) > 0 {
+		found := false
+		for _, logType := range filter.LogType {
+			if logType == l.LogType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.LogIndex != 0 && filter.LogIndex != l.LogIndex {
+		return false
+	}
+	if filter.TransactionHash != "" && filter.TransactionHash != l.TransactionHash {
+		return false
+	}
+	if filter.ContractAddress != "" && filter.ContractAddress != l.ContractAddress {
+		return false
+	}
+	if len(filter.Event) == 0 {
+		return true
+	}
+
+	var event map[string]json.RawMessage
+	if err := json.Unmarshal(l.Event, &event); err != nil {
+		return false
+	}
+	for key, want := range filter.Event {
+		got, ok := event[key]
+		if !ok || !jsonEqual(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonEqual reports whether raw and v encode the same JSON value, so that 10,
+// 10.0 and int64(10) are equal.
+func jsonEqual(raw json.RawMessage, v interface{}) bool {
+	wantBytes, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+
+	var got, want interface{}
+	if json.Unmarshal(raw, &got) != nil || json.Unmarshal(wantBytes, &want) != nil {
+		return false
+	}
+
+	gotBytes, _ := json.Marshal(got)
+	wantBytes, _ = json.Marshal(want)
+	return bytes.Equal(gotBytes, wantBytes)
+}
@@ -6,6 +6,7 @@ import (
 
 	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/types"
 	"gitlab.com/2finance/2finance-network/blockchain/utils"
@@ -41,56 +42,56 @@ func (c *networkClient) AddToken(
 	transferable bool, assetType string) (types.ContractOutput, error) {
 
 	if symbol == "" {
-		return types.ContractOutput{}, fmt.Errorf("symbol not set")
+		return types.ContractOutput{}, validationErrorf("symbol not set")
 	}
 	if name == "" {
-		return types.ContractOutput{}, fmt.Errorf("name not set")
+		return types.ContractOutput{}, validationErrorf("name not set")
 	}
 	if totalSupply == "" {
-		return types.ContractOutput{}, fmt.Errorf("total supply not set")
+		return types.ContractOutput{}, validationErrorf("total supply not set")
 	}
 	if owner == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner not set")
+		return types.ContractOutput{}, validationErrorf("owner not set")
 	}
 	if creator == "" {
-		return types.ContractOutput{}, fmt.Errorf("creator not set")
+		return types.ContractOutput{}, validationErrorf("creator not set")
 	}
 	if creatorWebsite == "" {
-		return types.ContractOutput{}, fmt.Errorf("creator website not set")
+		return types.ContractOutput{}, validationErrorf("creator website not set")
 	}
 	if image == "" {
-		return types.ContractOutput{}, fmt.Errorf("image not set")
+		return types.ContractOutput{}, validationErrorf("image not set")
 	}
 	if website == "" {
-		return types.ContractOutput{}, fmt.Errorf("website not set")
+		return types.ContractOutput{}, validationErrorf("website not set")
 	}
 	if feeAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("fee address not set")
+		return types.ContractOutput{}, validationErrorf("fee address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(feeAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid fee address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid fee address: %w", err)
 	}
 	if assetGLBUri == "" {
-		return types.ContractOutput{}, fmt.Errorf("asset GLB URI not set")
+		return types.ContractOutput{}, validationErrorf("asset GLB URI not set")
 	}
 	if tokenType == "" {
-		return types.ContractOutput{}, fmt.Errorf("token type not set")
+		return types.ContractOutput{}, validationErrorf("token type not set")
 	}
 
 	err := domain.ValidateUserMap(allowedUsers, "allowed users")
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid allowed users: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid allowed users: %w", err)
 	}
 
 	err = domain.ValidateUserMap(blockedUsers, "blocked users")
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid blocked users: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid blocked users: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	to := address
@@ -132,7 +133,7 @@ func (c *networkClient) AddToken(
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -152,31 +153,31 @@ func (c *networkClient) AddToken(
 // amount is the amount of tokens to mint, it should be in the smallest unit (e.g. wei for ETH)
 func (c *networkClient) MintToken(to, mintTo, amount string) (types.ContractOutput, error) {
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if to == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if mintTo == "" {
-		return types.ContractOutput{}, fmt.Errorf("mint to address not set")
+		return types.ContractOutput{}, validationErrorf("mint to address not set")
 	}
 	if amount == "" {
-		return types.ContractOutput{}, fmt.Errorf("amount not set")
+		return types.ContractOutput{}, validationErrorf("amount not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(mintTo); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid mint to address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid mint to address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(to); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(mintTo); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid mint to address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid mint to address: %w", err)
 	}
 
 	method := tokenV1.METHOD_MINT_TOKEN
@@ -191,7 +192,7 @@ func (c *networkClient) MintToken(to, mintTo, amount string) (types.ContractOutp
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -206,18 +207,18 @@ func (c *networkClient) MintToken(to, mintTo, amount string) (types.ContractOutp
 }
 
 func (c *networkClient) BurnToken(to, amount string, tokenUUIDList []string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if to == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(to); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_BURN_TOKEN
@@ -235,7 +236,7 @@ func (c *networkClient) BurnToken(to, amount string, tokenUUIDList []string) (ty
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -250,29 +251,29 @@ func (c *networkClient) BurnToken(to, amount string, tokenUUIDList []string) (ty
 }
 
 func (c *networkClient) TransferToken(tokenAddress string, transferTo string, amount string, tokenUUIDList []string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if transferTo == "" {
-		return types.ContractOutput{}, fmt.Errorf("to address not set")
+		return types.ContractOutput{}, validationErrorf("to address not set")
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if from == transferTo {
-		return types.ContractOutput{}, fmt.Errorf("from and to addresses are the same")
+		return types.ContractOutput{}, validationErrorf("from and to addresses are the same")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(transferTo); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid to address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid to address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_TRANSFER_TOKEN
@@ -291,7 +292,7 @@ func (c *networkClient) TransferToken(tokenAddress string, transferTo string, am
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -306,25 +307,25 @@ func (c *networkClient) TransferToken(tokenAddress string, transferTo string, am
 }
 
 func (c *networkClient) FreezeWallet(tokenAddress string, wallet string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if wallet == "" {
-		return types.ContractOutput{}, fmt.Errorf("wallet not set")
+		return types.ContractOutput{}, validationErrorf("wallet not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(wallet); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid wallet: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid wallet: %w", err)
 	}
 
 	method := tokenV1.METHOD_FREEZE_WALLET
@@ -337,7 +338,7 @@ func (c *networkClient) FreezeWallet(tokenAddress string, wallet string) (types.
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -352,25 +353,25 @@ func (c *networkClient) FreezeWallet(tokenAddress string, wallet string) (types.
 }
 
 func (c *networkClient) UnfreezeWallet(tokenAddress string, wallet string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if wallet == "" {
-		return types.ContractOutput{}, fmt.Errorf("wallet not set")
+		return types.ContractOutput{}, validationErrorf("wallet not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(wallet); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid wallet: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid wallet: %w", err)
 	}
 
 	method := tokenV1.METHOD_UNFREEZE_WALLET
@@ -383,7 +384,7 @@ func (c *networkClient) UnfreezeWallet(tokenAddress string, wallet string) (type
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -398,27 +399,27 @@ func (c *networkClient) UnfreezeWallet(tokenAddress string, wallet string) (type
 }
 
 func (c *networkClient) AddAllowedUsers(tokenAddress string, allowedUsers map[string]bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if len(allowedUsers) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("allowed users map is empty")
+		return types.ContractOutput{}, validationErrorf("allowed users map is empty")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	err := domain.ValidateUserMap(allowedUsers, "allow users")
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid allow users: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid allow users: %w", err)
 	}
 
 	method := tokenV1.METHOD_ADD_ALLOWED_USERS
@@ -432,7 +433,7 @@ func (c *networkClient) AddAllowedUsers(tokenAddress string, allowedUsers map[st
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -448,26 +449,26 @@ func (c *networkClient) AddAllowedUsers(tokenAddress string, allowedUsers map[st
 }
 
 func (c *networkClient) RemoveAllowedUsers(tokenAddress string, allowedUsers map[string]bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if len(allowedUsers) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("allowed users map is empty")
+		return types.ContractOutput{}, validationErrorf("allowed users map is empty")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	err := domain.ValidateUserMap(allowedUsers, "disallow users")
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid disallow users: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid disallow users: %w", err)
 	}
 
 	method := tokenV1.METHOD_REMOVE_ALLOWED_USERS
@@ -480,7 +481,7 @@ func (c *networkClient) RemoveAllowedUsers(tokenAddress string, allowedUsers map
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -496,26 +497,26 @@ func (c *networkClient) RemoveAllowedUsers(tokenAddress string, allowedUsers map
 }
 
 func (c *networkClient) AddBlockedUsers(tokenAddress string, blockedUsers map[string]bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if len(blockedUsers) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("blocked users map is empty")
+		return types.ContractOutput{}, validationErrorf("blocked users map is empty")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	err := domain.ValidateUserMap(blockedUsers, "block users")
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid block users: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid block users: %w", err)
 	}
 
 	method := tokenV1.METHOD_ADD_BLOCKED_USERS
@@ -528,7 +529,7 @@ func (c *networkClient) AddBlockedUsers(tokenAddress string, blockedUsers map[st
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -544,26 +545,26 @@ func (c *networkClient) AddBlockedUsers(tokenAddress string, blockedUsers map[st
 }
 
 func (c *networkClient) RemoveBlockedUsers(tokenAddress string, blockedUsers map[string]bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if len(blockedUsers) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("blocked users map is empty")
+		return types.ContractOutput{}, validationErrorf("blocked users map is empty")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	err := domain.ValidateUserMap(blockedUsers, "unblock users")
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid unblock users: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid unblock users: %w", err)
 	}
 
 	method := tokenV1.METHOD_REMOVE_BLOCKED_USERS
@@ -576,7 +577,7 @@ func (c *networkClient) RemoveBlockedUsers(tokenAddress string, blockedUsers map
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -592,18 +593,18 @@ func (c *networkClient) RemoveBlockedUsers(tokenAddress string, blockedUsers map
 }
 
 func (c *networkClient) RevokeFreezeAuthority(tokenAddress string, revoke bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_REVOKE_FREEZE_AUTHORITY
@@ -616,7 +617,7 @@ func (c *networkClient) RevokeFreezeAuthority(tokenAddress string, revoke bool)
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -631,18 +632,18 @@ func (c *networkClient) RevokeFreezeAuthority(tokenAddress string, revoke bool)
 }
 
 func (c *networkClient) RevokeMintAuthority(tokenAddress string, revoke bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_REVOKE_MINT_AUTHORITY
@@ -655,7 +656,7 @@ func (c *networkClient) RevokeMintAuthority(tokenAddress string, revoke bool) (t
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -670,18 +671,18 @@ func (c *networkClient) RevokeMintAuthority(tokenAddress string, revoke bool) (t
 }
 
 func (c *networkClient) RevokeUpdateAuthority(tokenAddress string, revoke bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_REVOKE_UPDATE_AUTHORITY
@@ -694,7 +695,7 @@ func (c *networkClient) RevokeUpdateAuthority(tokenAddress string, revoke bool)
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -711,38 +712,38 @@ func (c *networkClient) RevokeUpdateAuthority(tokenAddress string, revoke bool)
 func (c *networkClient) UpdateMetadata(tokenAddress, symbol, name string, decimals int, description, image, website string,
 	tagsSocialMedia, tagsCategory, tags map[string]string,
 	creator, creatorWebsite string, expired_at time.Time) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if symbol == "" {
-		return types.ContractOutput{}, fmt.Errorf("symbol not set")
+		return types.ContractOutput{}, validationErrorf("symbol not set")
 	}
 	if name == "" {
-		return types.ContractOutput{}, fmt.Errorf("name not set")
+		return types.ContractOutput{}, validationErrorf("name not set")
 	}
 	if description == "" {
-		return types.ContractOutput{}, fmt.Errorf("description not set")
+		return types.ContractOutput{}, validationErrorf("description not set")
 	}
 	if image == "" {
-		return types.ContractOutput{}, fmt.Errorf("image not set")
+		return types.ContractOutput{}, validationErrorf("image not set")
 	}
 	if website == "" {
-		return types.ContractOutput{}, fmt.Errorf("website not set")
+		return types.ContractOutput{}, validationErrorf("website not set")
 	}
 	if creator == "" {
-		return types.ContractOutput{}, fmt.Errorf("creator not set")
+		return types.ContractOutput{}, validationErrorf("creator not set")
 	}
 	if creatorWebsite == "" {
-		return types.ContractOutput{}, fmt.Errorf("creator website not set")
+		return types.ContractOutput{}, validationErrorf("creator website not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 	method := tokenV1.METHOD_UPDATE_METADATA
 	data := map[string]interface{}{
@@ -765,7 +766,7 @@ func (c *networkClient) UpdateMetadata(tokenAddress, symbol, name string, decima
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -780,22 +781,22 @@ func (c *networkClient) UpdateMetadata(tokenAddress, symbol, name string, decima
 }
 
 func (c *networkClient) PauseToken(tokenAddress string, paused bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if paused != true {
-		return types.ContractOutput{}, fmt.Errorf("paused must be true to pause token")
+		return types.ContractOutput{}, validationErrorf("paused must be true to pause token")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_PAUSE_TOKEN
@@ -808,7 +809,7 @@ func (c *networkClient) PauseToken(tokenAddress string, paused bool) (types.Cont
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -822,22 +823,22 @@ func (c *networkClient) PauseToken(tokenAddress string, paused bool) (types.Cont
 	return contractOutput, nil
 }
 func (c *networkClient) UnpauseToken(tokenAddress string, paused bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if paused != false {
-		return types.ContractOutput{}, fmt.Errorf("paused must be false to unpause token")
+		return types.ContractOutput{}, validationErrorf("paused must be false to unpause token")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_UNPAUSE_TOKEN
@@ -850,7 +851,7 @@ func (c *networkClient) UnpauseToken(tokenAddress string, paused bool) (types.Co
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -865,21 +866,21 @@ func (c *networkClient) UnpauseToken(tokenAddress string, paused bool) (types.Co
 }
 
 func (c *networkClient) UpdateFeeTiers(tokenAddress string, feeTiersList []map[string]interface{}) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if len(feeTiersList) == 0 {
-		return types.ContractOutput{}, fmt.Errorf("fee tiers list is empty")
+		return types.ContractOutput{}, validationErrorf("fee tiers list is empty")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_UPDATE_FEE_TIERS
@@ -892,7 +893,7 @@ func (c *networkClient) UpdateFeeTiers(tokenAddress string, feeTiersList []map[s
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -907,21 +908,21 @@ func (c *networkClient) UpdateFeeTiers(tokenAddress string, feeTiersList []map[s
 }
 
 func (c *networkClient) UpdateFeeAddress(tokenAddress, feeAddress string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if feeAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("fee address not set")
+		return types.ContractOutput{}, validationErrorf("fee address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_UPDATE_FEE_ADDRESS
@@ -934,7 +935,7 @@ func (c *networkClient) UpdateFeeAddress(tokenAddress, feeAddress string) (types
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -949,23 +950,23 @@ func (c *networkClient) UpdateFeeAddress(tokenAddress, feeAddress string) (types
 }
 
 func (c *networkClient) UpdateGlbFile(tokenAddress string, newAssetGLBUri string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if newAssetGLBUri == "" {
-		return types.ContractOutput{}, fmt.Errorf("new asset GLB URI not set")
+		return types.ContractOutput{}, validationErrorf("new asset GLB URI not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_UPDATE_GLB_FILE
@@ -978,7 +979,7 @@ func (c *networkClient) UpdateGlbFile(tokenAddress string, newAssetGLBUri string
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -993,20 +994,20 @@ func (c *networkClient) UpdateGlbFile(tokenAddress string, newAssetGLBUri string
 }
 
 func (c *networkClient) TransferableToken(tokenAddress string, transferable bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_TRANSFERABLE_TOKEN
@@ -1019,7 +1020,7 @@ func (c *networkClient) TransferableToken(tokenAddress string, transferable bool
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -1034,20 +1035,20 @@ func (c *networkClient) TransferableToken(tokenAddress string, transferable bool
 }
 
 func (c *networkClient) UntransferableToken(tokenAddress string, transferable bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 	if from == "" {
-		return types.ContractOutput{}, fmt.Errorf("from address not set")
+		return types.ContractOutput{}, validationErrorf("from address not set")
 	}
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_UNTRANSFERABLE_TOKEN
@@ -1061,7 +1062,7 @@ func (c *networkClient) UntransferableToken(tokenAddress string, transferable bo
 	}
 
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		tokenAddress,
 		method,
@@ -1076,18 +1077,18 @@ func (c *networkClient) UntransferableToken(tokenAddress string, transferable bo
 }
 
 func (c *networkClient) GetToken(tokenAddress string, symbol string, name string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" && symbol == "" && name == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address, symbol or name must be set")
+		return types.ContractOutput{}, validationErrorf("token address, symbol or name must be set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	method := tokenV1.METHOD_GET_TOKEN
@@ -1105,16 +1106,16 @@ func (c *networkClient) GetToken(tokenAddress string, symbol string, name string
 }
 
 func (c *networkClient) ListTokens(ownerAddress, symbol, name, tokenType string, page, limit int, ascending bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if ownerAddress != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(ownerAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 		}
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	method := tokenV1.METHOD_LIST_TOKENS
@@ -1141,25 +1142,25 @@ func (c *networkClient) ListTokens(ownerAddress, symbol, name, tokenType string,
 }
 
 func (c *networkClient) GetTokenBalance(tokenAddress, ownerAddress string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if ownerAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner address not set")
+		return types.ContractOutput{}, validationErrorf("owner address not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(ownerAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 	}
 
 	method := tokenV1.METHOD_GET_TOKEN_BALANCE
@@ -1176,28 +1177,28 @@ func (c *networkClient) GetTokenBalance(tokenAddress, ownerAddress string) (type
 }
 
 func (c *networkClient) GetTokenBalanceNFT(tokenAddress string, ownerAddress string, tokenUUID string) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if tokenAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("token address not set")
+		return types.ContractOutput{}, validationErrorf("token address not set")
 	}
 	if ownerAddress == "" {
-		return types.ContractOutput{}, fmt.Errorf("owner address not set")
+		return types.ContractOutput{}, validationErrorf("owner address not set")
 	}
 	if tokenUUID == "" {
-		return types.ContractOutput{}, fmt.Errorf("token UUID not set")
+		return types.ContractOutput{}, validationErrorf("token UUID not set")
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 	}
 
 	if err := keys.ValidateEDDSAPublicKeyHex(ownerAddress); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 	}
 
 	method := tokenV1.METHOD_GET_TOKEN_BALANCE_NFT
@@ -1215,21 +1216,21 @@ func (c *networkClient) GetTokenBalanceNFT(tokenAddress string, ownerAddress str
 }
 
 func (c *networkClient) ListTokenBalances(tokenAddress, ownerAddress, tokenType string, page, limit int, ascending bool) (types.ContractOutput, error) {
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	if err := keys.ValidateEDDSAPublicKeyHex(from); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid from address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid from address: %w", err)
 	}
 
 	if tokenAddress != "" {
 
 		if err := keys.ValidateEDDSAPublicKeyHex(tokenAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid token address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid token address: %w", err)
 		}
 	}
 	if ownerAddress != "" {
 		if err := keys.ValidateEDDSAPublicKeyHex(ownerAddress); err != nil {
-			return types.ContractOutput{}, fmt.Errorf("invalid owner address: %w", err)
+			return types.ContractOutput{}, validationErrorf("invalid owner address: %w", err)
 		}
 	}
 
@@ -1253,3 +1254,60 @@ func (c *networkClient) ListTokenBalances(tokenAddress, ownerAddress, tokenType
 
 	return contractOutput, nil
 }
+
+// DecodeToken returns the token created by AddToken.
+//
+//	token, err := client_2finance.DecodeToken(c.AddToken(...))
+func DecodeToken(out types.ContractOutput, err error) (domain.Token, error) {
+	return decodeEventResult[domain.Token](out, err, domain.TOKEN_CREATED_LOG)
+}
+
+// DecodeMintFT returns the fungible mint performed by MintToken.
+func DecodeMintFT(out types.ContractOutput, err error) (domain.MintFT, error) {
+	return decodeEventResult[domain.MintFT](out, err, domain.TOKEN_MINTED_FT_LOG)
+}
+
+// DecodeMintNFT returns the non-fungible mint performed by MintToken.
+func DecodeMintNFT(out types.ContractOutput, err error) (domain.MintNFT, error) {
+	return decodeEventResult[domain.MintNFT](out, err, domain.TOKEN_MINTED_NFT_LOG)
+}
+
+// DecodeBurnFT returns the fungible burn performed by BurnToken.
+func DecodeBurnFT(out types.ContractOutput, err error) (domain.BurnFT, error) {
+	return decodeEventResult[domain.BurnFT](out, err, domain.TOKEN_BURNED_FT_LOG)
+}
+
+// DecodeBurnNFT returns the non-fungible burn performed by BurnToken.
+func DecodeBurnNFT(out types.ContractOutput, err error) (domain.BurnNFT, error) {
+	return decodeEventResult[domain.BurnNFT](out, err, domain.TOKEN_BURNED_NFT_LOG)
+}
+
+// DecodeTransferFT returns the fungible transfer performed by TransferToken.
+func DecodeTransferFT(out types.ContractOutput, err error) (domain.TransferFT, error) {
+	return decodeEventResult[domain.TransferFT](out, err, domain.TOKEN_TRANSFERRED_FT_LOG)
+}
+
+// DecodeTransferNFT returns the non-fungible transfer performed by TransferToken.
+func DecodeTransferNFT(out types.ContractOutput, err error) (domain.TransferNFT, error) {
+	return decodeEventResult[domain.TransferNFT](out, err, domain.TOKEN_TRANSFERRED_NFT_LOG)
+}
+
+// DecodeTokenState returns the token read by GetToken.
+func DecodeTokenState(out types.ContractOutput, err error) (models.TokenStateModel, error) {
+	return decodeStateResult[models.TokenStateModel](out, err)
+}
+
+// DecodeTokens returns the tokens read by ListTokens.
+func DecodeTokens(out types.ContractOutput, err error) ([]models.TokenStateModel, error) {
+	return decodeStateResult[[]models.TokenStateModel](out, err)
+}
+
+// DecodeTokenBalance returns the balance read by GetTokenBalance or GetTokenBalanceNFT.
+func DecodeTokenBalance(out types.ContractOutput, err error) (models.BalanceStateModel, error) {
+	return decodeStateResult[models.BalanceStateModel](out, err)
+}
+
+// DecodeTokenBalances returns the balances read by ListTokenBalances.
+func DecodeTokenBalances(out types.ContractOutput, err error) ([]models.BalanceStateModel, error) {
+	return decodeStateResult[[]models.BalanceStateModel](out, err)
+}
@@ -0,0 +1,29 @@
+package client_2finance
+
+import (
+	"context"
+
+	"gitlab.com/2finance/2finance-network/infra/event"
+)
+
+// Transport carries requests to a node and returns its responses. The
+// contract methods only talk to the node through a Transport, so any
+// implementation (MQTT, WebSocket JSON-RPC, an in-process fake) can back a
+// client built by NewWithTransport.
+//
+// Implementations must be safe for concurrent use and must give up when ctx
+// is done, returning an error that wraps ctx.Err().
+type Transport interface {
+	// RoundTrip sends request and waits for its response. replyTo names the
+	// caller's reply channel; transports without reply channels ignore it.
+	RoundTrip(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error)
+}
+
+// TransportFunc adapts a function to a Transport. It is handy for in-memory
+// loopback transports in tests.
+type TransportFunc func(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error)
+
+// RoundTrip calls f.
+func (f TransportFunc) RoundTrip(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error) {
+	return f(ctx, replyTo, request)
+}
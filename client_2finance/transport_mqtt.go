@@ -0,0 +1,161 @@
+package client_2finance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gitlab.com/2finance/2finance-network/infra/event"
+	"gitlab.com/2finance/2finance-network/infra/mqtt"
+)
+
+// mqttTransport is the default Transport. The node takes requests on the
+// request topic with one more level, the reply channel, and answers on the
+// response topic with that same level. Every request is sent with a reply
+// channel of its own, a slot named <replyTo>-<n>, so that its response cannot
+// be handed to another caller.
+type mqttTransport struct {
+	responses *responseMux
+}
+
+// NewMQTTTransport returns a Transport over an already connected MQTT client.
+func NewMQTTTransport(mqttClient mqtt.IMQTT) Transport {
+	return &mqttTransport{
+		responses: newResponseMux(mqttClient),
+	}
+}
+
+func (t *mqttTransport) RoundTrip(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error) {
+	slot, responseChan, err := t.responses.acquire(replyTo)
+	if err != nil {
+		return event.ResponsePayload{}, fmt.Errorf("failed to subscribe to reply topic: %w", err)
+	}
+
+	answered := false
+	defer func() { t.responses.release(replyTo, slot, answered) }()
+
+	// Use the original topic and append the reply slot
+	base := strings.TrimSuffix(event.TRANSACTIONS_REQUEST_TOPIC, "/+")
+	requestTopic := fmt.Sprintf("%s/%s", base, slot)
+	if err := t.responses.publish(requestTopic, request); err != nil {
+		return event.ResponsePayload{}, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var data []byte
+	select {
+	case data = <-responseChan:
+		answered = true
+	case <-ctx.Done():
+		return event.ResponsePayload{}, fmt.Errorf("request aborted waiting for response on topic %s/%s: %w", event.TRANSACTIONS_RESPONSE_TOPIC, slot, ctx.Err())
+	}
+
+	var resp event.ResponsePayload
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return event.ResponsePayload{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// responseMux owns the reply slots of an mqttTransport. A slot is subscribed
+// once, for the lifetime of the client, and carries one request at a time:
+// its response is the next message on its topic. Slots are reused once
+// answered, so a client holds about as many subscriptions as it has requests
+// in flight at once. A slot whose request was abandoned could still receive
+// the late response, so it is never used again.
+type responseMux struct {
+	mqttClient mqtt.IMQTT
+
+	mu      sync.Mutex
+	next    map[string]int
+	free    map[string][]string
+	pending map[string]chan []byte
+}
+
+func newResponseMux(mqttClient mqtt.IMQTT) *responseMux {
+	return &responseMux{
+		mqttClient: mqttClient,
+		next:       make(map[string]int),
+		free:       make(map[string][]string),
+		pending:    make(map[string]chan []byte),
+	}
+}
+
+// acquire reserves a slot of replyTo with no request in flight, subscribing
+// a new one when every slot is busy, and returns the channel its response
+// will be delivered on.
+func (m *responseMux) acquire(replyTo string) (string, <-chan []byte, error) {
+	ch := make(chan []byte, 1)
+
+	m.mu.Lock()
+	if free := m.free[replyTo]; len(free) > 0 {
+		slot := free[len(free)-1]
+		m.free[replyTo] = free[:len(free)-1]
+		m.pending[slot] = ch
+		m.mu.Unlock()
+		return slot, ch, nil
+	}
+	m.next[replyTo]++
+	slot := fmt.Sprintf("%s-%d", replyTo, m.next[replyTo])
+	m.pending[slot] = ch
+	m.mu.Unlock()
+
+	topic := fmt.Sprintf("%s/%s", event.TRANSACTIONS_RESPONSE_TOPIC, slot)
+	if err := m.mqttClient.SubscribeWithHandler(topic, func(_ mqtt.Client, msg mqtt.Message) {
+		m.dispatch(msg.Topic(), msg.Payload())
+	}); err != nil {
+		m.release(replyTo, slot, false)
+		return "", nil, err
+	}
+
+	return slot, ch, nil
+}
+
+// release ends the request on slot. An answered slot goes back to the pool
+// of replyTo; any other is retired, and late responses on it are dropped.
+func (m *responseMux) release(replyTo, slot string, answered bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.pending, slot)
+	if answered {
+		m.free[replyTo] = append(m.free[replyTo], slot)
+	}
+}
+
+// dispatch hands a response to the caller waiting on its slot. Responses
+// nobody waits for, late or not meant for this client, are dropped.
+func (m *responseMux) dispatch(topic string, data []byte) {
+	slot, ok := strings.CutPrefix(topic, event.TRANSACTIONS_RESPONSE_TOPIC+"/")
+	if !ok {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch, ok := m.pending[slot]
+	if !ok {
+		return
+	}
+	delete(m.pending, slot)
+
+	// Buffered with capacity 1 and delivered at most once, never blocks.
+	ch <- data
+}
+
+// publish sends payload to topic.
+func (m *responseMux) publish(topic string, payload event.RequestPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload error: %w", err)
+	}
+
+	if err := m.mqttClient.Publish(topic, data); err != nil {
+		return fmt.Errorf("publish error: %w", err)
+	}
+
+	return nil
+}
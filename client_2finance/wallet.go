@@ -3,6 +3,8 @@ package client_2finance
 import (
 	"fmt"
 
+	walletV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/walletV1/domain"
+	walletV1Models "gitlab.com/2finance/2finance-network/blockchain/contract/walletV1/models"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 
 	"gitlab.com/2finance/2finance-network/blockchain/contract/walletV1"
@@ -20,19 +22,19 @@ import (
 // if the amonut is 0,0000000001, and decimals is 18, the amount in database will be 100000000
 func (c *networkClient) AddWallet(address, pubKey string) (types.ContractOutput, error) {
 	if pubKey == "" {
-		return types.ContractOutput{}, fmt.Errorf("public key not set")
+		return types.ContractOutput{}, validationErrorf("public key not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(pubKey); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid public key: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid public key: %w", err)
 	}
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("contract address not set")
+		return types.ContractOutput{}, validationErrorf("contract address not set")
 	}
 	if err := keys.ValidateEDDSAPublicKeyHex(address); err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid contract address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid contract address: %w", err)
 	}
 
-	from := c.walletManager.GetPublicKey()
+	from := c.signerPublicKey()
 
 	to := address
 	method := walletV1.METHOD_ADD_WALLET
@@ -46,7 +48,7 @@ func (c *networkClient) AddWallet(address, pubKey string) (types.ContractOutput,
 		return types.ContractOutput{}, fmt.Errorf("failed to generate UUIDv7: %w", err)
 	}
 	contractOutput, err := c.SignAndSendTransaction(
-		c.chainId,
+		c.GetChainID(),
 		from,
 		to,
 		method,
@@ -63,11 +65,11 @@ func (c *networkClient) AddWallet(address, pubKey string) (types.ContractOutput,
 func (c *networkClient) GetWalletByPublicKey(pubKey string) (types.ContractOutput, error) {
 
 	if pubKey == "" {
-		return types.ContractOutput{}, fmt.Errorf("public key not set")
+		return types.ContractOutput{}, validationErrorf("public key not set")
 	}
 	err := keys.ValidateEDDSAPublicKeyHex(pubKey)
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid public key: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid public key: %w", err)
 	}
 
 	method := walletV1.METHOD_GET_WALLET_BY_PUBLIC_KEY
@@ -86,11 +88,11 @@ func (c *networkClient) GetWalletByPublicKey(pubKey string) (types.ContractOutpu
 
 func (c *networkClient) GetWalletByAddress(address string) (types.ContractOutput, error) {
 	if address == "" {
-		return types.ContractOutput{}, fmt.Errorf("contract address not set")
+		return types.ContractOutput{}, validationErrorf("contract address not set")
 	}
 	err := keys.ValidateEDDSAPublicKeyHex(address)
 	if err != nil {
-		return types.ContractOutput{}, fmt.Errorf("invalid contract address: %w", err)
+		return types.ContractOutput{}, validationErrorf("invalid contract address: %w", err)
 	}
 
 	method := walletV1.METHOD_GET_WALLET_BY_ADDRESS
@@ -105,3 +107,13 @@ func (c *networkClient) GetWalletByAddress(address string) (types.ContractOutput
 
 	return contractOutput, nil
 }
+
+// DecodeWallet returns the wallet created by AddWallet.
+func DecodeWallet(out types.ContractOutput, err error) (walletV1Domain.Wallet, error) {
+	return decodeEventResult[walletV1Domain.Wallet](out, err, walletV1Domain.WALLET_CREATED_LOG)
+}
+
+// DecodeWalletState returns the wallet read by GetWalletByPublicKey or GetWalletByAddress.
+func DecodeWalletState(out types.ContractOutput, err error) (walletV1Models.WalletStateModel, error) {
+	return decodeStateResult[walletV1Models.WalletStateModel](out, err)
+}
@@ -1,17 +1,26 @@
 package e2e_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/2Finance-Labs/go-client-2finance/client_2finance/client_2financetest"
 	"github.com/stretchr/testify/assert"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/contractV1/domain"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/walletV1"
 	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
 	"gitlab.com/2finance/2finance-network/blockchain/log"
 	"gitlab.com/2finance/2finance-network/blockchain/transaction"
 	"gitlab.com/2finance/2finance-network/blockchain/utils"
+	"gitlab.com/2finance/2finance-network/blockchain/virtualmachine"
+	"gitlab.com/2finance/2finance-network/infra/event"
 )
 
 func TestContractDeployment1(t *testing.T) {
@@ -285,4 +294,654 @@ func Test_TransactionRoundtrip_Sanity(t *testing.T) {
 	)
 
 	_ = tx.Get()
-}
\ No newline at end of file
+}
+
+func Test_WithContext_CanceledContextAbortsRequest(t *testing.T) {
+	signer := setupSignerWallet(t)
+	c := setupClient(t, signer.Wallet)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err := c.WithContext(ctx).ListBlocks(0, time.Time{}, "", "", "", 1, 5, true)
+	if err == nil {
+		t.Fatalf("expected error for canceled context")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("canceled request should return immediately")
+	}
+
+	_, err = c.WithContext(ctx).DeployContract1(walletV1.WALLET_CONTRACT_V1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled from contract method, got %v", err)
+	}
+}
+
+func Test_WithContext_DeadlineExceeded(t *testing.T) {
+	signer := setupSignerWallet(t)
+	c := setupClient(t, signer.Wallet)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	_, err := c.GetStateContext(ctx, "", walletV1.METHOD_GET_WALLET_BY_PUBLIC_KEY, map[string]interface{}{
+		"public_key": signer.PublicKey,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !errors.Is(err, client2f.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func Test_ConcurrentRequestsShareOneClient(t *testing.T) {
+	signer := setupSignerWallet(t)
+	c := setupClient(t, signer.Wallet)
+
+	useWallet(t, c, signer.Wallet)
+
+	const workers = 8
+
+	// One block per page: every caller asks for a different block, so a
+	// response handed to the wrong caller shows as the wrong hash.
+	want := make([]string, workers+1)
+	for page := 1; page <= workers; page++ {
+		blocks, err := c.ListBlocks(0, time.Time{}, "", "", "", page, 1, true)
+		if err != nil {
+			t.Fatalf("ListBlocks(page=%d): %v", page, err)
+		}
+		if len(blocks) != 1 {
+			t.Skipf("chain has fewer than %d blocks", workers)
+		}
+		want[page] = blocks[0].Hash
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for i := 1; i <= workers; i++ {
+		wg.Add(1)
+		go func(page int) {
+			defer wg.Done()
+
+			blocks, err := c.ListBlocks(0, time.Time{}, "", "", "", page, 1, true)
+			if err != nil {
+				errs <- fmt.Errorf("ListBlocks(page=%d): %w", page, err)
+				return
+			}
+			if len(blocks) != 1 || blocks[0].Hash != want[page] {
+				errs <- fmt.Errorf("ListBlocks(page=%d) got %v, want block %s: response routed to wrong caller", page, blocks, want[page])
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func Test_StructuredErrors(t *testing.T) {
+	signer := setupSignerWallet(t)
+	c := setupClient(t, signer.Wallet)
+
+	// Validation failures never reach the node.
+	_, err := c.AddWallet("not-a-hex-address", signer.PublicKey)
+	if !errors.Is(err, client2f.ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+
+	var contractErr *client2f.ContractError
+	if errors.As(err, &contractErr) {
+		t.Fatalf("validation error must not be a ContractError: %v", err)
+	}
+
+	// A locked wallet refuses to sign.
+	if err := signer.Wallet.Lock(); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	_, err = c.DeployContract1(walletV1.WALLET_CONTRACT_V1)
+	if !errors.Is(err, client2f.ErrWalletLocked) {
+		t.Fatalf("expected ErrWalletLocked, got %v", err)
+	}
+}
+
+func Test_New_ValidatesOptions(t *testing.T) {
+	wm := setupWalletManager(t)
+
+	cases := map[string]struct {
+		broker string
+		opts   []client2f.Option
+	}{
+		"empty broker":          {broker: ""},
+		"unsupported scheme":    {broker: "http://localhost:1883"},
+		"missing host":          {broker: "tcp://:1883"},
+		"missing chain id":      {broker: "tcp://localhost:1883"},
+		"invalid chain id":      {broker: "tcp://localhost:1883", opts: []client2f.Option{client2f.WithChainID(3)}},
+		"nil wallet manager":    {broker: "tcp://localhost:1883", opts: []client2f.Option{client2f.WithWalletManager(nil)}},
+		"empty client id":       {broker: "tcp://localhost:1883", opts: []client2f.Option{client2f.WithClientID(" ")}},
+		"wildcard client id":    {broker: "tcp://localhost:1883", opts: []client2f.Option{client2f.WithClientID("e2e/#")}},
+		"zero response timeout": {broker: "tcp://localhost:1883", opts: []client2f.Option{client2f.WithResponseTimeout(0)}},
+		"valid options, bad one last": {broker: "tcp://localhost:1883", opts: []client2f.Option{
+			client2f.WithChainID(client2f.ChainIDTestnet),
+			client2f.WithWalletManager(wm),
+			client2f.WithResponseTimeout(-time.Second),
+		}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := client2f.New(tc.broker, tc.opts...)
+			if !errors.Is(err, client2f.ErrValidation) {
+				t.Fatalf("expected ErrValidation, got %v", err)
+			}
+			if c != nil {
+				t.Fatalf("expected nil client on error")
+			}
+		})
+	}
+}
+
+func Test_SetChainIDAndWalletManager_ReturnErrors(t *testing.T) {
+	signer := setupSignerWallet(t)
+	c := setupClient(t, signer.Wallet)
+
+	if err := c.SetChainID(0); !errors.Is(err, client2f.ErrValidation) {
+		t.Fatalf("SetChainID(0): expected ErrValidation, got %v", err)
+	}
+	if err := c.SetWalletManager(nil); !errors.Is(err, client2f.ErrValidation) {
+		t.Fatalf("SetWalletManager(nil): expected ErrValidation, got %v", err)
+	}
+
+	// The client keeps working with its previous configuration.
+	if _, err := c.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+		t.Fatalf("DeployContract1 after rejected setters: %v", err)
+	}
+}
+
+func Test_Setters_ApplyToCopiesConcurrently(t *testing.T) {
+	first := setupSignerWallet(t)
+	second := setupSignerWallet(t)
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(first.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	bound := c.WithContext(context.Background())
+
+	// A change made on the client applies to the copies made before it.
+	if err := c.SetChainID(client2f.ChainIDMainnet); err != nil {
+		t.Fatalf("SetChainID: %v", err)
+	}
+	if _, err := bound.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+		t.Fatalf("DeployContract1: %v", err)
+	}
+	assert.Equal(t, client2f.ChainIDMainnet, node.Transactions()[0].ChainID)
+
+	// Setters may run while calls are in flight.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = c.SetChainID(client2f.ChainIDTestnet)
+			_ = c.SetWalletManager(second.Wallet)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := bound.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+				t.Errorf("DeployContract1: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := bound.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+		t.Fatalf("DeployContract1: %v", err)
+	}
+	txs := node.Transactions()
+	assert.Equal(t, second.PublicKey, txs[len(txs)-1].From, "the copy signs with the wallet set last")
+}
+
+func Test_NewWithTransport_InMemoryLoopback(t *testing.T) {
+	var seen []string
+	loopback := client2f.TransportFunc(func(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error) {
+		seen = append(seen, request.Method)
+		if request.Method == virtualmachine.REQUEST_METHOD_GET_LOGS {
+			return event.ResponsePayload{Status: event.RESPONSE_STATUS_ERROR, Message: "logs disabled"}, nil
+		}
+		return event.ResponsePayload{Data: map[string]interface{}{"states": []interface{}{}}}, nil
+	})
+
+	c, err := client2f.NewWithTransport(loopback, client2f.WithChainID(client2f.ChainIDTestnet))
+	if err != nil {
+		t.Fatalf("NewWithTransport: %v", err)
+	}
+
+	if _, err := c.GetState("", walletV1.METHOD_GET_WALLET_BY_PUBLIC_KEY, map[string]interface{}{}); err != nil {
+		t.Fatalf("GetState over loopback: %v", err)
+	}
+
+	_, err = c.ListLogs(nil, 0, "", nil, "addr", 1, 10, true)
+	var contractErr *client2f.ContractError
+	if !errors.As(err, &contractErr) || contractErr.Message != "logs disabled" {
+		t.Fatalf("expected ContractError from loopback, got %v", err)
+	}
+
+	assert.Equal(t, []string{virtualmachine.REQUEST_METHOD_GET_STATE, virtualmachine.REQUEST_METHOD_GET_LOGS}, seen)
+}
+
+func Test_NewWithTransport_ResponseTimeout(t *testing.T) {
+	blocking := client2f.TransportFunc(func(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error) {
+		<-ctx.Done()
+		return event.ResponsePayload{}, ctx.Err()
+	})
+
+	c, err := client2f.NewWithTransport(blocking,
+		client2f.WithChainID(client2f.ChainIDTestnet),
+		client2f.WithResponseTimeout(10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewWithTransport: %v", err)
+	}
+
+	_, err = c.GetState("", walletV1.METHOD_GET_WALLET_BY_PUBLIC_KEY, map[string]interface{}{})
+	if !errors.Is(err, client2f.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	if _, err := client2f.NewWithTransport(nil); !errors.Is(err, client2f.ErrValidation) {
+		t.Fatalf("expected ErrValidation for nil transport, got %v", err)
+	}
+}
+
+// lossyTransport forwards requests to a fake node and drops the responses to
+// the first drop sends, optionally after the node committed them.
+type lossyTransport struct {
+	node   *client_2financetest.Node
+	drop   int
+	commit bool
+	hashes []string
+}
+
+func (l *lossyTransport) RoundTrip(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error) {
+	if request.Method != virtualmachine.REQUEST_METHOD_SEND {
+		return l.node.RoundTrip(ctx, replyTo, request)
+	}
+
+	tx := request.Params.(*transaction.Transaction)
+	l.hashes = append(l.hashes, tx.Hash)
+	if len(l.hashes) > l.drop {
+		return l.node.RoundTrip(ctx, replyTo, request)
+	}
+
+	if l.commit {
+		if _, err := l.node.RoundTrip(ctx, replyTo, request); err != nil {
+			return event.ResponsePayload{}, err
+		}
+	}
+	<-ctx.Done()
+	return event.ResponsePayload{}, ctx.Err()
+}
+
+func Test_RetryPolicy_ResendsSameSignedTransaction(t *testing.T) {
+	signer := setupSignerWallet(t)
+	lossy := &lossyTransport{node: client_2financetest.NewNode(), drop: 1}
+
+	c, err := client2f.NewWithTransport(lossy,
+		client2f.WithChainID(client2f.ChainIDTestnet),
+		client2f.WithWalletManager(signer.Wallet),
+		client2f.WithResponseTimeout(50*time.Millisecond),
+		client2f.WithRetryPolicy(client2f.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("NewWithTransport: %v", err)
+	}
+
+	if _, err := c.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+		t.Fatalf("DeployContract1: %v", err)
+	}
+
+	assert.Len(t, lossy.hashes, 2, "sends")
+	assert.Equal(t, lossy.hashes[0], lossy.hashes[1], "retry must re-send the same signed transaction")
+	assert.Len(t, lossy.node.Transactions(), 1, "committed transactions")
+}
+
+func Test_RetryPolicy_DetectsCommittedTransaction(t *testing.T) {
+	signer := setupSignerWallet(t)
+	lossy := &lossyTransport{node: client_2financetest.NewNode(), drop: 1, commit: true}
+
+	c, err := client2f.NewWithTransport(lossy,
+		client2f.WithChainID(client2f.ChainIDTestnet),
+		client2f.WithWalletManager(signer.Wallet),
+		client2f.WithResponseTimeout(50*time.Millisecond),
+		client2f.WithRetryPolicy(client2f.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("NewWithTransport: %v", err)
+	}
+
+	// The output of the committed transaction is rebuilt from its logs.
+	contract, err := client2f.DecodeDeployedContract(c.DeployContract1(walletV1.WALLET_CONTRACT_V1))
+	if err != nil {
+		t.Fatalf("DeployContract1: %v", err)
+	}
+	assert.NotEmpty(t, contract.Address)
+
+	assert.Len(t, lossy.hashes, 1, "a committed transaction must not be re-sent")
+	if assert.Len(t, lossy.node.Transactions(), 1, "committed transactions") {
+		assert.Equal(t, lossy.hashes[0], lossy.node.Transactions()[0].Hash)
+	}
+
+	if _, err := client2f.NewWithTransport(lossy, client2f.WithChainID(client2f.ChainIDTestnet), client2f.WithRetryPolicy(client2f.RetryPolicy{})); !errors.Is(err, client2f.ErrValidation) {
+		t.Fatalf("expected ErrValidation for zero attempts, got %v", err)
+	}
+}
+
+func Test_Outbox_IdempotencyKey(t *testing.T) {
+	signer := setupSignerWallet(t)
+	lossy := &lossyTransport{node: client_2financetest.NewNode(), drop: 1, commit: true}
+
+	outbox, err := client2f.OpenOutbox(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenOutbox: %v", err)
+	}
+
+	c, err := client2f.NewWithTransport(lossy,
+		client2f.WithChainID(client2f.ChainIDTestnet),
+		client2f.WithWalletManager(signer.Wallet),
+		client2f.WithResponseTimeout(50*time.Millisecond),
+		client2f.WithOutbox(outbox),
+	)
+	if err != nil {
+		t.Fatalf("NewWithTransport: %v", err)
+	}
+
+	deploy := c.WithContext(client2f.WithIdempotencyKey(context.Background(), "deploy-wallet"))
+
+	// The node commits the transaction but the response is lost.
+	if _, err := deploy.DeployContract1(walletV1.WALLET_CONTRACT_V1); !errors.Is(err, client2f.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	entry, found, err := outbox.Entry("deploy-wallet")
+	if err != nil || !found {
+		t.Fatalf("Entry: %v, found %v", err, found)
+	}
+	assert.Equal(t, client2f.OutboxStatusSent, entry.Status)
+	assert.Equal(t, lossy.hashes[0], entry.Transaction.Hash)
+
+	// Calling again does not sign a second transaction, and returns the
+	// output of the committed one.
+	contract, err := client2f.DecodeDeployedContract(deploy.DeployContract1(walletV1.WALLET_CONTRACT_V1))
+	if err != nil {
+		t.Fatalf("DeployContract1 again: %v", err)
+	}
+	assert.NotEmpty(t, contract.Address)
+	assert.Len(t, lossy.hashes, 1, "a committed transaction must not be re-sent")
+
+	entry, _, err = outbox.Entry("deploy-wallet")
+	if err != nil {
+		t.Fatalf("Entry: %v", err)
+	}
+	assert.Equal(t, client2f.OutboxStatusCommitted, entry.Status)
+	assert.NotEmpty(t, entry.Output, "the rebuilt output is recorded")
+
+	_, err = deploy.DeployContract1(tokenV1.TOKEN_CONTRACT_V1)
+	if !errors.Is(err, client2f.ErrIdempotencyConflict) {
+		t.Fatalf("expected ErrIdempotencyConflict, got %v", err)
+	}
+
+	// A completed operation returns its recorded output.
+	token := c.WithContext(client2f.WithIdempotencyKey(context.Background(), "deploy-token"))
+	first, err := token.DeployContract1(tokenV1.TOKEN_CONTRACT_V1)
+	if err != nil {
+		t.Fatalf("DeployContract1: %v", err)
+	}
+	again, err := token.DeployContract1(tokenV1.TOKEN_CONTRACT_V1)
+	if err != nil {
+		t.Fatalf("DeployContract1 again: %v", err)
+	}
+	firstJSON, _ := json.Marshal(first)
+	againJSON, _ := json.Marshal(again)
+	assert.JSONEq(t, string(firstJSON), string(againJSON))
+	assert.Len(t, lossy.node.Transactions(), 2, "committed transactions")
+
+	// Calls without a key are not kept once committed.
+	if _, err := c.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+		t.Fatalf("DeployContract1 without a key: %v", err)
+	}
+	assert.Len(t, lossy.node.Transactions(), 3, "committed transactions")
+
+	entries, err := outbox.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "deploy-wallet", entries[0].Key)
+		assert.Equal(t, client2f.OutboxStatusCommitted, entries[0].Status)
+		assert.Equal(t, client2f.OutboxStatusCommitted, entries[1].Status)
+		assert.Equal(t, 1, entries[1].Attempts)
+	}
+}
+
+func Test_Outbox_SharedDirectory(t *testing.T) {
+	signer := setupSignerWallet(t)
+	node := client_2financetest.NewNode()
+	dir := t.TempDir()
+
+	// Each outbox stands for a process: only the lock files keep them from
+	// signing the operation twice.
+	const processes = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, processes)
+	for i := 0; i < processes; i++ {
+		outbox, err := client2f.OpenOutbox(dir)
+		if err != nil {
+			t.Fatalf("OpenOutbox: %v", err)
+		}
+		c, err := node.NewClient(client2f.WithWalletManager(signer.Wallet), client2f.WithOutbox(outbox))
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			deploy := c.WithContext(client2f.WithIdempotencyKey(context.Background(), "deploy-wallet"))
+			if _, err := deploy.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("DeployContract1: %v", err)
+	}
+
+	assert.Len(t, node.Transactions(), 1, "one operation is signed and sent once")
+
+	outbox, err := client2f.OpenOutbox(dir)
+	if err != nil {
+		t.Fatalf("OpenOutbox: %v", err)
+	}
+	entries, err := outbox.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, client2f.OutboxStatusCommitted, entries[0].Status)
+		assert.Equal(t, 1, entries[0].Attempts)
+	}
+}
+
+func Test_Outbox_ResubmitsAfterRestart(t *testing.T) {
+	signer := setupSignerWallet(t)
+	lossy := &lossyTransport{node: client_2financetest.NewNode(), drop: 1}
+	dir := t.TempDir()
+
+	outbox, err := client2f.OpenOutbox(dir)
+	if err != nil {
+		t.Fatalf("OpenOutbox: %v", err)
+	}
+
+	c, err := client2f.NewWithTransport(lossy,
+		client2f.WithChainID(client2f.ChainIDTestnet),
+		client2f.WithWalletManager(signer.Wallet),
+		client2f.WithResponseTimeout(50*time.Millisecond),
+		client2f.WithOutbox(outbox),
+	)
+	if err != nil {
+		t.Fatalf("NewWithTransport: %v", err)
+	}
+
+	// The transaction never reaches the node.
+	if _, err := c.DeployContract1(walletV1.WALLET_CONTRACT_V1); !errors.Is(err, client2f.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	assert.Empty(t, lossy.node.Transactions())
+
+	// After a restart the outbox is reopened; resubmitting needs no wallet.
+	reopened, err := client2f.OpenOutbox(dir)
+	if err != nil {
+		t.Fatalf("OpenOutbox: %v", err)
+	}
+	restarted, err := client2f.NewWithTransport(lossy,
+		client2f.WithChainID(client2f.ChainIDTestnet),
+		client2f.WithOutbox(reopened),
+	)
+	if err != nil {
+		t.Fatalf("NewWithTransport: %v", err)
+	}
+
+	resubmitted, err := restarted.ResubmitOutbox(context.Background())
+	if err != nil {
+		t.Fatalf("ResubmitOutbox: %v", err)
+	}
+	if assert.Len(t, resubmitted, 1) {
+		assert.Equal(t, client2f.OutboxStatusCommitted, resubmitted[0].Status)
+		assert.Equal(t, 2, resubmitted[0].Attempts)
+		assert.NotEmpty(t, resubmitted[0].Output)
+	}
+
+	assert.Len(t, lossy.hashes, 2, "sends")
+	assert.Equal(t, lossy.hashes[0], lossy.hashes[1], "resubmission must re-send the same signed transaction")
+	assert.Len(t, lossy.node.Transactions(), 1, "committed transactions")
+
+	resubmitted, err = restarted.ResubmitOutbox(context.Background())
+	if err != nil {
+		t.Fatalf("ResubmitOutbox: %v", err)
+	}
+	assert.Empty(t, resubmitted, "nothing is left to resubmit")
+
+	entries, err := reopened.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	assert.Empty(t, entries, "unkeyed entries are dropped once committed")
+}
+
+func Test_WaitForTransaction_Confirmations(t *testing.T) {
+	signer := setupSignerWallet(t)
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(signer.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	deployed, err := c.DeployContract1(walletV1.WALLET_CONTRACT_V1)
+	if err != nil {
+		t.Fatalf("DeployContract1: %v", err)
+	}
+	hash := node.Transactions()[0].Hash
+
+	confirmation, err := c.WaitForTransaction(context.Background(), hash, client2f.WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("WaitForTransaction: %v", err)
+	}
+	assert.Equal(t, hash, confirmation.Transaction.Hash)
+	assert.Equal(t, uint64(1), confirmation.Block.Number)
+	assert.Equal(t, uint64(1), confirmation.Confirmations)
+	assert.Len(t, confirmation.Logs, len(deployed.Logs), "logs")
+
+	// The transaction is not buried deep enough until two more blocks exist.
+	_, err = c.WaitForTransaction(context.Background(), hash,
+		client2f.WithConfirmations(3),
+		client2f.WithPollInterval(time.Millisecond),
+		client2f.WithWaitTimeout(20*time.Millisecond),
+	)
+	if !errors.Is(err, client2f.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+			t.Fatalf("DeployContract1: %v", err)
+		}
+	}
+
+	confirmation, err = c.WaitForTransaction(context.Background(), hash,
+		client2f.WithConfirmations(3),
+		client2f.WithPollInterval(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("WaitForTransaction: %v", err)
+	}
+	assert.Equal(t, uint64(3), confirmation.Confirmations)
+
+	if _, err := c.WaitForTransaction(context.Background(), hash, client2f.WithConfirmations(0)); !errors.Is(err, client2f.ErrValidation) {
+		t.Fatalf("expected ErrValidation for zero confirmations, got %v", err)
+	}
+}
+
+func Test_WaitForTransaction_Lookback(t *testing.T) {
+	signer := setupSignerWallet(t)
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(signer.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := c.DeployContract1(walletV1.WALLET_CONTRACT_V1); err != nil {
+			t.Fatalf("DeployContract1: %v", err)
+		}
+	}
+	hash := node.Transactions()[0].Hash
+
+	// The transaction is in block 1, older than the last two blocks.
+	_, err = c.WaitForTransaction(context.Background(), hash,
+		client2f.WithLookback(2),
+		client2f.WithPollInterval(time.Millisecond),
+		client2f.WithWaitTimeout(20*time.Millisecond),
+	)
+	if !errors.Is(err, client2f.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	confirmation, err := c.WaitForTransaction(context.Background(), hash,
+		client2f.WithLookback(5),
+		client2f.WithPollInterval(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("WaitForTransaction: %v", err)
+	}
+	assert.Equal(t, uint64(1), confirmation.Block.Number)
+	assert.Equal(t, uint64(5), confirmation.Confirmations)
+
+	if _, err := c.WaitForTransaction(context.Background(), hash, client2f.WithLookback(0)); !errors.Is(err, client2f.ErrValidation) {
+		t.Fatalf("expected ErrValidation for zero lookback, got %v", err)
+	}
+}
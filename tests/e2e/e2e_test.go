@@ -72,8 +72,15 @@ func setupClient(t *testing.T, wallet wallet_manager.IWalletManager) client2f.Cl
 
 	id := fmt.Sprintf("%s-%s", base, randSuffix(8))
 
-	c := client2f.New(emqxHost, id, false, wallet)
-	c.SetChainID(config.CHAIN_ID)
+	c, err := client2f.New(
+		emqxHost,
+		client2f.WithClientID(id),
+		client2f.WithChainID(config.CHAIN_ID),
+		client2f.WithWalletManager(wallet),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
 
 	return c
 }
@@ -177,5 +184,7 @@ func importAndUnlockWallet(t *testing.T, wm wallet_manager.IWalletManager, expec
 func useWallet(t *testing.T, c client2f.Client2FinanceNetwork, wm wallet_manager.IWalletManager) {
 	t.Helper()
 
-	c.SetWalletManager(wm)
+	if err := c.SetWalletManager(wm); err != nil {
+		t.Fatalf("SetWalletManager: %v", err)
+	}
 }
@@ -0,0 +1,506 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/2Finance-Labs/go-client-2finance/client_2finance/client_2financetest"
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
+	tokenV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1/domain"
+	walletV1Domain "gitlab.com/2finance/2finance-network/blockchain/contract/walletV1/domain"
+	"gitlab.com/2finance/2finance-network/blockchain/log"
+	"gitlab.com/2finance/2finance-network/blockchain/types"
+	"gitlab.com/2finance/2finance-network/blockchain/utils"
+	"gitlab.com/2finance/2finance-network/infra/event"
+)
+
+func Test_FakeNode_TokenFlowFungible(t *testing.T) {
+	owner := setupSignerWallet(t)
+	receiver := setupSignerWallet(t)
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(owner.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	createWallet(t, c, owner.PublicKey)
+	useWallet(t, c, receiver.Wallet)
+	createWallet(t, c, receiver.PublicKey)
+
+	useWallet(t, c, owner.Wallet)
+	tok := createBasicToken(t, c, owner.PublicKey, 0, false, tokenV1Domain.FUNGIBLE, false)
+
+	_, err = client2f.DecodeMintFT(c.MintToken(tok.Address, owner.PublicKey, "50"))
+	if err != nil {
+		t.Fatalf("MintToken: %v", err)
+	}
+
+	transferOut, err := c.TransferToken(tok.Address, receiver.PublicKey, "150", nil)
+	if err != nil {
+		t.Fatalf("TransferToken: %v", err)
+	}
+	require.Len(t, transferOut.Logs, 3, "transfer logs")
+
+	ownerBalance, err := client2f.DecodeTokenBalance(c.GetTokenBalance(tok.Address, owner.PublicKey))
+	if err != nil {
+		t.Fatalf("GetTokenBalance (owner): %v", err)
+	}
+	assert.Equal(t, "99999900", ownerBalance.Amount, "owner balance after transfer")
+
+	receiverBalance, err := client2f.DecodeTokenBalance(c.GetTokenBalance(tok.Address, receiver.PublicKey))
+	if err != nil {
+		t.Fatalf("GetTokenBalance (receiver): %v", err)
+	}
+	assert.Equal(t, "150", receiverBalance.Amount, "receiver balance after transfer")
+
+	// The receiver cannot spend more than it holds.
+	useWallet(t, c, receiver.Wallet)
+	_, err = c.TransferToken(tok.Address, owner.PublicKey, "151", nil)
+	var contractErr *client2f.ContractError
+	require.ErrorAs(t, err, &contractErr)
+	assert.Equal(t, tokenV1.METHOD_TRANSFER_TOKEN, contractErr.Method)
+
+	// Only the owner can mint.
+	_, err = c.MintToken(tok.Address, receiver.PublicKey, "1")
+	require.ErrorAs(t, err, &contractErr)
+
+	txs := node.Transactions()
+	require.Len(t, txs, 8, "committed transactions")
+
+	listed, err := c.ListTransactions(receiver.PublicKey, "", "", nil, 0, 1, 10, true)
+	if err != nil {
+		t.Fatalf("ListTransactions: %v", err)
+	}
+	assert.Len(t, listed, 2, "receiver transactions")
+}
+
+func Test_FakeNode_SubscribeLogsAndBlocks(t *testing.T) {
+	first := setupSignerWallet(t)
+	second := setupSignerWallet(t)
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(first.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	logs, err := c.SubscribeLogs(ctx, log.LogParams{
+		LogType: []string{walletV1Domain.WALLET_CREATED_LOG},
+		Event:   map[string]interface{}{"public_key": second.PublicKey},
+	}, client2f.WithSubscribePollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("SubscribeLogs: %v", err)
+	}
+	blocks, err := c.SubscribeBlocks(ctx, client2f.WithSubscribePollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("SubscribeBlocks: %v", err)
+	}
+
+	createWallet(t, c, first.PublicKey)
+	useWallet(t, c, second.Wallet)
+	createWallet(t, c, second.PublicKey)
+
+	// Only the second wallet's creation log matches the filter.
+	select {
+	case lg := <-logs:
+		assert.Equal(t, walletV1Domain.WALLET_CREATED_LOG, lg.LogType)
+		wallet, err := utils.UnmarshalEvent[walletV1Domain.Wallet](lg.Event)
+		require.NoError(t, err)
+		assert.Equal(t, second.PublicKey, wallet.PublicKey)
+	case <-ctx.Done():
+		t.Fatalf("no log received: %v", ctx.Err())
+	}
+
+	// Two deploys and two wallet creations, one block each.
+	for want := uint64(1); want <= 4; want++ {
+		select {
+		case blk := <-blocks:
+			assert.Equal(t, want, blk.Number, "block number")
+			require.Len(t, blk.Transactions, 1)
+		case <-ctx.Done():
+			t.Fatalf("block %d not received: %v", want, ctx.Err())
+		}
+	}
+
+	cancel()
+	if _, open := <-logs; open {
+		t.Fatalf("logs channel still open after cancel")
+	}
+
+	if _, err := c.SubscribeBlocks(context.Background(), client2f.WithSubscribePollInterval(0)); !errors.Is(err, client2f.ErrValidation) {
+		t.Fatalf("expected ErrValidation for zero poll interval, got %v", err)
+	}
+}
+
+func Test_FakeNode_SubscribeReportsFailures(t *testing.T) {
+	node := client_2financetest.NewNode()
+
+	// The node answers the first request, which finds the latest block,
+	// and is unreachable afterwards.
+	var requests atomic.Int32
+	down := errors.New("node unreachable")
+	c, err := client2f.NewWithTransport(client2f.TransportFunc(func(ctx context.Context, replyTo string, request event.RequestPayload) (event.ResponsePayload, error) {
+		if requests.Add(1) > 1 {
+			return event.ResponsePayload{}, down
+		}
+		return node.RoundTrip(ctx, replyTo, request)
+	}), client2f.WithChainID(client2f.ChainIDTestnet))
+	if err != nil {
+		t.Fatalf("NewWithTransport: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var failures []error
+	blocks, err := c.SubscribeBlocks(ctx,
+		client2f.WithSubscribePollInterval(time.Millisecond),
+		client2f.WithSubscribeErrors(func(err error) { failures = append(failures, err) }),
+	)
+	if err != nil {
+		t.Fatalf("SubscribeBlocks: %v", err)
+	}
+
+	select {
+	case _, open := <-blocks:
+		require.False(t, open, "no block is committed")
+	case <-ctx.Done():
+		t.Fatalf("subscription still running after repeated failures: %v", ctx.Err())
+	}
+
+	require.Len(t, failures, 10)
+	for _, err := range failures {
+		require.ErrorIs(t, err, down)
+	}
+	require.ErrorIs(t, failures[len(failures)-1], client2f.ErrSubscriptionFailed)
+	require.NotErrorIs(t, failures[0], client2f.ErrSubscriptionFailed)
+
+	_, err = c.SubscribeBlocks(ctx, client2f.WithSubscribeErrors(nil))
+	require.ErrorIs(t, err, client2f.ErrValidation)
+}
+
+func Test_FakeNode_WithSignerPerCall(t *testing.T) {
+	owner := setupSignerWallet(t)
+	receiver := setupSignerWallet(t)
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(owner.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	createWallet(t, c, owner.PublicKey)
+	asReceiver := c.WithContext(client2f.WithSigner(context.Background(), receiver.Wallet))
+	createWallet(t, asReceiver, receiver.PublicKey)
+
+	tok := createBasicToken(t, c, owner.PublicKey, 0, false, tokenV1Domain.FUNGIBLE, false)
+	if _, err := c.TransferToken(tok.Address, receiver.PublicKey, "100", nil); err != nil {
+		t.Fatalf("TransferToken: %v", err)
+	}
+
+	// Both parties share one client; neither call swaps its wallet manager.
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := c.TransferToken(tok.Address, receiver.PublicKey, "10", nil)
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := asReceiver.TransferToken(tok.Address, owner.PublicKey, "1", nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	ownerBalance, err := client2f.DecodeTokenBalance(c.GetTokenBalance(tok.Address, owner.PublicKey))
+	if err != nil {
+		t.Fatalf("GetTokenBalance (owner): %v", err)
+	}
+	assert.Equal(t, "99999855", ownerBalance.Amount, "owner balance")
+
+	receiverBalance, err := client2f.DecodeTokenBalance(c.GetTokenBalance(tok.Address, receiver.PublicKey))
+	if err != nil {
+		t.Fatalf("GetTokenBalance (receiver): %v", err)
+	}
+	assert.Equal(t, "145", receiverBalance.Amount, "receiver balance")
+
+	signer, ok := client2f.SignerFromContext(client2f.WithSigner(context.Background(), receiver.Wallet))
+	require.True(t, ok)
+	assert.Equal(t, receiver.PublicKey, signer.GetPublicKey())
+}
+
+func Test_FakeNode_PasswordRequiredMethod(t *testing.T) {
+	owner := setupSignerWallet(t)
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(owner.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	createWallet(t, c, owner.PublicKey)
+	tok := createBasicToken(t, c, owner.PublicKey, 0, false, tokenV1Domain.FUNGIBLE, false)
+
+	owner.Wallet.SetPasswordRequired(tokenV1.METHOD_REVOKE_MINT_AUTHORITY, true)
+
+	_, err = c.RevokeMintAuthority(tok.Address, true)
+	require.ErrorIs(t, err, client2f.ErrPasswordRequired)
+
+	_, err = c.WithContext(client2f.WithPassword(context.Background(), E2E_WALLET_PASSWORD)).RevokeMintAuthority(tok.Address, true)
+	require.NoError(t, err)
+
+	var contractErr *client2f.ContractError
+	_, err = c.MintToken(tok.Address, owner.PublicKey, "1")
+	require.ErrorAs(t, err, &contractErr, "mint authority must be revoked")
+
+	// Other methods sign without the password until the wallet locks.
+	_, err = c.BurnToken(tok.Address, "1", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, owner.Wallet.Lock())
+	_, err = c.BurnToken(tok.Address, "1", nil)
+	require.ErrorIs(t, err, client2f.ErrWalletLocked)
+
+	var lockedErr *wallet_manager.WalletLockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, tokenV1.METHOD_BURN_TOKEN, lockedErr.Method)
+}
+
+func Test_FakeNode_MultisigRevokeMintAuthority(t *testing.T) {
+	owner := setupSignerWallet(t)
+	officers := []e2eSigner{setupSignerWallet(t), setupSignerWallet(t), setupSignerWallet(t)}
+	signers := []string{officers[0].PublicKey, officers[1].PublicKey, officers[2].PublicKey}
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(owner.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	createWallet(t, c, owner.PublicKey)
+	tok := createBasicToken(t, c, owner.PublicKey, 0, false, tokenV1Domain.FUNGIBLE, false)
+
+	uuid7, err := utils.NewUUID7()
+	if err != nil {
+		t.Fatalf("NewUUID7: %v", err)
+	}
+
+	m, err := wallet_manager.NewMultisigTransaction(client2f.ChainIDTestnet, owner.PublicKey, tok.Address,
+		tokenV1.METHOD_REVOKE_MINT_AUTHORITY, utils.JSONB{"revoked": true}, 1, uuid7, 2, signers)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Approve(officers[0].Wallet))
+	require.Error(t, m.Approve(owner.Wallet), "the owner is not one of the signers")
+
+	_, err = c.SendMultisigTransaction(context.Background(), m)
+	require.ErrorIs(t, err, client2f.ErrMultisigThreshold)
+
+	// The second officer approves a serialized copy on another machine.
+	envelope, err := json.Marshal(m)
+	require.NoError(t, err)
+	remote, err := wallet_manager.ParseMultisigTransaction(envelope)
+	require.NoError(t, err)
+	signature, err := officers[1].Wallet.SignApproval(remote)
+	require.NoError(t, err)
+	_, err = owner.Wallet.SignApproval(remote)
+	require.Error(t, err, "only a listed signer approves")
+
+	require.Error(t, m.AddApproval(officers[2].PublicKey, signature), "signature of another key")
+	require.NoError(t, m.AddApproval(officers[1].PublicKey, signature))
+
+	approvers, err := m.Approvers()
+	require.NoError(t, err)
+	assert.Equal(t, signers[:2], approvers)
+
+	// Editing the transaction voids the approvals.
+	tampered, err := wallet_manager.ParseMultisigTransaction(envelope)
+	require.NoError(t, err)
+	tampered.Approvals = m.Approvals
+	tampered.Transaction.To = owner.PublicKey
+	_, err = c.SendMultisigTransaction(context.Background(), tampered)
+	require.ErrorIs(t, err, client2f.ErrMultisigThreshold)
+
+	_, err = c.SendMultisigTransaction(context.Background(), m)
+	require.NoError(t, err)
+
+	var contractErr *client2f.ContractError
+	_, err = c.MintToken(tok.Address, owner.PublicKey, "1")
+	require.ErrorAs(t, err, &contractErr, "mint authority must be revoked")
+}
+
+func Test_FakeNode_OfflineBuildSignBroadcast(t *testing.T) {
+	cold := setupSignerWallet(t)
+	receiver := setupSignerWallet(t)
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(cold.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	createWallet(t, c, cold.PublicKey)
+	tok := createBasicToken(t, c, cold.PublicKey, 0, false, tokenV1Domain.FUNGIBLE, false)
+
+	// The online machine knows the cold wallet's public key only.
+	online, err := node.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	unsigned, err := online.BuildTransaction(cold.PublicKey, func(c client2f.Client2FinanceNetwork) (types.ContractOutput, error) {
+		return c.TransferToken(tok.Address, receiver.PublicKey, "25", nil)
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(unsigned, client2f.OfflineTransactionPrefix))
+	assert.Regexp(t, `^[A-Z0-9:]+$`, unsigned, "QR alphanumeric mode")
+
+	tx, err := client2f.DecodeOfflineTransaction(unsigned)
+	require.NoError(t, err)
+	assert.Equal(t, cold.PublicKey, tx.From)
+	assert.Equal(t, tokenV1.METHOD_TRANSFER_TOKEN, tx.Method)
+	assert.Empty(t, tx.Signature)
+
+	_, err = online.BroadcastSignedTransaction(context.Background(), unsigned)
+	require.ErrorIs(t, err, client2f.ErrValidation, "unsigned transactions are not sent")
+
+	_, err = online.BuildTransaction(cold.PublicKey, func(c client2f.Client2FinanceNetwork) (types.ContractOutput, error) {
+		return c.TransferToken(tok.Address, cold.PublicKey, "25", nil)
+	})
+	require.ErrorIs(t, err, client2f.ErrValidation, "methods run their checks")
+
+	// The air-gapped machine signs with the locked wallet and its password.
+	require.NoError(t, cold.Wallet.Lock())
+	_, err = client2f.SignTransactionOffline(cold.Wallet, "", unsigned)
+	require.ErrorIs(t, err, client2f.ErrWalletLocked)
+
+	signed, err := client2f.SignTransactionOffline(cold.Wallet, E2E_WALLET_PASSWORD, unsigned)
+	require.NoError(t, err)
+
+	damaged := []byte(signed)
+	i := len(client2f.OfflineTransactionPrefix) + 10
+	damaged[i] = map[bool]byte{true: 'B', false: 'A'}[damaged[i] == 'A']
+	_, err = online.BroadcastSignedTransaction(context.Background(), string(damaged))
+	require.ErrorIs(t, err, client2f.ErrValidation)
+
+	// Line breaks from a wrapped file are ignored.
+	wrapped := signed[:40] + "\n" + signed[40:] + "\n"
+	_, err = online.BroadcastSignedTransaction(context.Background(), wrapped)
+	require.NoError(t, err)
+
+	balance, err := client2f.DecodeTokenBalance(c.GetTokenBalance(tok.Address, receiver.PublicKey))
+	if err != nil {
+		t.Fatalf("GetTokenBalance: %v", err)
+	}
+	assert.Equal(t, "25", balance.Amount)
+}
+
+func Test_FakeNode_SendBatch(t *testing.T) {
+	owner := setupSignerWallet(t)
+	frozen := setupSignerWallet(t)
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithWalletManager(owner.Wallet))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	createWallet(t, c, owner.PublicKey)
+	tok := createBasicToken(t, c, owner.PublicKey, 0, false, tokenV1Domain.FUNGIBLE, false)
+
+	_, err = c.FreezeWallet(tok.Address, frozen.PublicKey)
+	require.NoError(t, err)
+
+	transfer := func(to string) client2f.BatchCall {
+		return func(c client2f.Client2FinanceNetwork) (types.ContractOutput, error) {
+			return c.TransferToken(tok.Address, to, "1", nil)
+		}
+	}
+
+	receivers := make([]string, 12)
+	calls := make([]client2f.BatchCall, len(receivers))
+	for i := range receivers {
+		receivers[i], _ = genKey(t, owner.Wallet)
+		calls[i] = transfer(receivers[i])
+	}
+	calls[5] = transfer(frozen.PublicKey)
+
+	committed := len(node.Transactions())
+
+	var progress []client2f.BatchProgress
+	results, err := c.SendBatch(context.Background(), calls,
+		client2f.WithBatchConcurrency(4),
+		client2f.WithBatchProgress(func(p client2f.BatchProgress) { progress = append(progress, p) }),
+	)
+
+	var contractErr *client2f.ContractError
+	require.ErrorAs(t, err, &contractErr, "the transfer to the frozen account fails")
+	require.Len(t, results, len(calls))
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		require.NotNil(t, result.Transaction)
+		if i == 5 {
+			assert.ErrorAs(t, result.Err, &contractErr)
+			continue
+		}
+		assert.NoError(t, result.Err, "item %d", i)
+		assert.NotEmpty(t, result.Output.Logs, "item %d", i)
+	}
+	assert.Len(t, node.Transactions(), committed+len(calls)-1)
+
+	require.Len(t, progress, len(calls))
+	last := progress[len(progress)-1]
+	assert.Equal(t, len(calls), last.Done)
+	assert.Equal(t, len(calls), last.Total)
+	assert.Equal(t, 1, last.Failed)
+
+	balance, err := client2f.DecodeTokenBalance(c.GetTokenBalance(tok.Address, receivers[11]))
+	if err != nil {
+		t.Fatalf("GetTokenBalance: %v", err)
+	}
+	assert.Equal(t, "1", balance.Amount)
+
+	// An item that cannot be built stops the batch before anything is sent.
+	committed = len(node.Transactions())
+	results, err = c.SendBatch(context.Background(), []client2f.BatchCall{transfer(receivers[0]), transfer(owner.PublicKey)}, client2f.WithStopOnError())
+	require.ErrorIs(t, err, client2f.ErrValidation)
+	assert.ErrorIs(t, results[0].Err, client2f.ErrBatchStopped)
+	assert.ErrorIs(t, results[1].Err, client2f.ErrValidation)
+	assert.Len(t, node.Transactions(), committed)
+
+	// A failed transaction stops the items not sent yet.
+	results, err = c.SendBatch(context.Background(),
+		[]client2f.BatchCall{transfer(receivers[0]), transfer(frozen.PublicKey), transfer(receivers[1]), transfer(receivers[2])},
+		client2f.WithStopOnError(), client2f.WithBatchConcurrency(1),
+	)
+	require.ErrorAs(t, err, &contractErr)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorAs(t, results[1].Err, &contractErr)
+	assert.ErrorIs(t, results[2].Err, client2f.ErrBatchStopped)
+	assert.ErrorIs(t, results[3].Err, client2f.ErrBatchStopped)
+	assert.Len(t, node.Transactions(), committed+1)
+
+	_, err = c.SendBatch(context.Background(), calls, client2f.WithBatchConcurrency(0))
+	require.ErrorIs(t, err, client2f.ErrValidation)
+}
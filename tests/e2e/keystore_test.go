@@ -0,0 +1,230 @@
+package e2e_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystoreE2E_AddListSelectRemove(t *testing.T) {
+	// -------------------------
+	// ARRANGE
+	// -------------------------
+	password := "StrongPassword123!"
+	otherPassword := "OtherPassword123!"
+	keystorePassword := "KeystorePassword123!"
+
+	keystoreDir := t.TempDir()
+	keystore := wallet_manager.NewKeystore(keystoreDir, keystorePassword)
+
+	generator := wallet_manager.NewWalletManager(filepath.Join(t.TempDir(), "generator.wallet"))
+	treasuryPublicKey, treasuryPrivateKey, err := generator.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+	payrollPublicKey, payrollPrivateKey, err := generator.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+
+	// -------------------------
+	// ACT: ADD ACCOUNTS
+	// -------------------------
+	treasury, err := keystore.AddAccount("treasury", []byte(treasuryPrivateKey), password)
+	require.NoError(t, err)
+	require.Equal(t, treasuryPublicKey, treasury.Owner)
+
+	payroll, err := keystore.AddAccount("payroll", []byte(payrollPrivateKey), otherPassword)
+	require.NoError(t, err)
+
+	// -------------------------
+	// ASSERT: DUPLICATES AND BAD LABELS
+	// -------------------------
+	_, err = keystore.AddAccount("treasury", []byte(payrollPrivateKey), password)
+	require.Error(t, err)
+
+	_, err = keystore.AddAccount("treasury-copy", []byte(treasuryPrivateKey), password)
+	require.Error(t, err)
+
+	_, err = keystore.AddAccount("../escape", []byte(treasuryPrivateKey), password)
+	require.Error(t, err)
+
+	// -------------------------
+	// ASSERT: LIST FROM A NEW INSTANCE
+	// -------------------------
+	accounts, err := wallet_manager.NewKeystore(keystoreDir, keystorePassword).ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	require.Equal(t, "payroll", accounts[0].Label)
+	require.Equal(t, payrollPublicKey, accounts[0].Owner)
+	require.Equal(t, "treasury", accounts[1].Label)
+
+	// -------------------------
+	// ASSERT: THE DIRECTORY DOES NOT TELL WHICH ACCOUNTS IT HOLDS
+	// -------------------------
+	index, err := os.ReadFile(filepath.Join(keystoreDir, "keystore.json"))
+	require.NoError(t, err)
+	require.NotContains(t, string(index), "treasury")
+	require.NotContains(t, string(index), treasuryPublicKey)
+
+	entries, err := os.ReadDir(keystoreDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.NotContains(t, entry.Name(), "treasury")
+		require.NotContains(t, entry.Name(), "payroll")
+	}
+
+	_, err = wallet_manager.NewKeystore(keystoreDir, "WrongPassword123!").ListAccounts()
+	require.Error(t, err, "the index needs the keystore password")
+
+	// -------------------------
+	// ACT & ASSERT: SELECT AND UNLOCK PER ACCOUNT
+	// -------------------------
+	treasuryWallet, err := keystore.Account("treasury")
+	require.NoError(t, err)
+	require.Equal(t, treasuryPublicKey, treasuryWallet.GetPublicKey())
+	require.False(t, treasuryWallet.IsUnlocked())
+
+	require.Error(t, treasuryWallet.Unlock(otherPassword))
+	require.NoError(t, treasuryWallet.Unlock(password))
+
+	sameWallet, err := keystore.AccountByPublicKey(treasuryPublicKey)
+	require.NoError(t, err)
+	require.True(t, sameWallet.IsUnlocked(), "lookups must share the unlocked account")
+
+	payrollWallet, err := keystore.Account("payroll")
+	require.NoError(t, err)
+	require.False(t, payrollWallet.IsUnlocked(), "accounts unlock independently")
+
+	signed, err := treasuryWallet.SignTransaction(2, treasuryPublicKey, payrollPublicKey, "Transfer", nil, 1, "uuid")
+	require.NoError(t, err)
+	require.NotEmpty(t, signed.Signature)
+
+	// -------------------------
+	// ACT & ASSERT: REMOVE
+	// -------------------------
+	require.Error(t, keystore.RemoveAccount("payroll", password))
+	require.NoError(t, keystore.RemoveAccount("payroll", otherPassword))
+
+	_, err = keystore.Account("payroll")
+	require.True(t, errors.Is(err, wallet_manager.ErrAccountNotFound))
+
+	_, err = os.Stat(filepath.Join(keystoreDir, payroll.FileName))
+	require.True(t, os.IsNotExist(err))
+
+	accounts, err = keystore.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+}
+
+func TestKeystoreE2E_ExportImportBackup(t *testing.T) {
+	// -------------------------
+	// ARRANGE
+	// -------------------------
+	password := "StrongPassword123!"
+	backupPassword := "BackupPassword123!"
+	newPassword := "NewMachinePassword123!"
+	keystorePassword := "KeystorePassword123!"
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	source := wallet_manager.NewKeystore(t.TempDir(), keystorePassword)
+
+	generator := wallet_manager.NewWalletManager(filepath.Join(t.TempDir(), "generator.wallet"))
+	_, treasuryPrivateKey, err := generator.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+
+	treasury, err := source.AddAccount("treasury", []byte(treasuryPrivateKey), password)
+	require.NoError(t, err)
+	derived, err := source.AddDerivedAccount("derived", mnemonic, "", 0, password)
+	require.NoError(t, err)
+
+	// -------------------------
+	// ACT: EXPORT
+	// -------------------------
+	_, err = source.ExportBackup(nil, "WrongPassword123!", backupPassword)
+	require.Error(t, err, "export needs the account password")
+
+	_, err = source.ExportBackup([]string{"missing"}, password, backupPassword)
+	require.True(t, errors.Is(err, wallet_manager.ErrAccountNotFound))
+
+	backup, err := source.ExportBackup(nil, password, backupPassword)
+	require.NoError(t, err)
+	require.NotContains(t, string(backup), treasuryPrivateKey)
+
+	// -------------------------
+	// ASSERT: SELF-DESCRIBING HEADER
+	// -------------------------
+	parsed, err := wallet_manager.ParseWalletBackup(backup)
+	require.NoError(t, err)
+	require.Equal(t, wallet_manager.WalletBackupFormat, parsed.Format)
+	require.Equal(t, "argon2id", parsed.KDF.Alg)
+	require.Len(t, parsed.Accounts, 2)
+	require.Equal(t, treasury.Owner, parsed.Accounts[0].Owner)
+	require.Equal(t, derived.Owner, parsed.Accounts[1].Owner)
+	require.Equal(t, derived.DerivationPath, parsed.Accounts[1].DerivationPath)
+
+	damaged := strings.Replace(string(backup), `"label": "treasury"`, `"label": "savings"`, 1)
+	_, err = wallet_manager.ParseWalletBackup([]byte(damaged))
+	require.ErrorContains(t, err, "checksum")
+
+	// A crafted header must not get to choose the cost of the key derivation.
+	for _, crafted := range [][2]string{
+		{`"time": 3`, `"time": 1000`},
+		{`"time": 3`, `"time": 0`},
+		{`"memory_kb": 131072`, `"memory_kb": 4194304`},
+		{`"parallel": 4`, `"parallel": 0`},
+		{`"key_len": 32`, `"key_len": 16`},
+	} {
+		require.Contains(t, string(backup), crafted[0])
+		_, err = wallet_manager.ParseWalletBackup([]byte(strings.Replace(string(backup), crafted[0], crafted[1], 1)))
+		require.ErrorContains(t, err, "KDF", crafted[1])
+	}
+
+	// -------------------------
+	// ACT & ASSERT: IMPORT ON ANOTHER MACHINE
+	// -------------------------
+	target := wallet_manager.NewKeystore(t.TempDir(), keystorePassword)
+
+	_, err = target.ImportBackup(backup, "WrongPassword123!", newPassword)
+	require.Error(t, err)
+
+	imported, err := target.ImportBackup(backup, backupPassword, newPassword)
+	require.NoError(t, err)
+	require.Len(t, imported, 2)
+
+	account, err := target.AccountByPublicKey(treasury.Owner)
+	require.NoError(t, err)
+	require.NoError(t, account.Unlock(newPassword))
+
+	accounts, err := target.ListAccounts()
+	require.NoError(t, err)
+	require.Equal(t, derived.DerivationPath, accounts[0].DerivationPath)
+
+	imported, err = target.ImportBackup(backup, backupPassword, newPassword)
+	require.NoError(t, err)
+	require.Empty(t, imported, "accounts already held are skipped")
+
+	// -------------------------
+	// ACT & ASSERT: SINGLE WALLET
+	// -------------------------
+	err = wallet_manager.NewWalletManager(filepath.Join(t.TempDir(), "all.wallet")).ImportBackup(backup, backupPassword, newPassword)
+	require.ErrorContains(t, err, "keystore", "a wallet holds one account")
+
+	single, err := source.ExportBackup([]string{"treasury"}, password, backupPassword)
+	require.NoError(t, err)
+
+	restored := wallet_manager.NewWalletManager(filepath.Join(t.TempDir(), "restored.wallet"))
+	require.NoError(t, restored.ImportBackup(single, backupPassword, newPassword))
+	require.NoError(t, restored.Unlock(newPassword))
+	require.Equal(t, treasury.Owner, restored.GetPublicKey())
+
+	require.NoError(t, restored.Lock())
+	roundTrip, err := restored.ExportBackup(newPassword, backupPassword)
+	require.NoError(t, err)
+	require.False(t, restored.IsUnlocked(), "exporting does not unlock the wallet")
+	parsed, err = wallet_manager.ParseWalletBackup(roundTrip)
+	require.NoError(t, err)
+	require.Equal(t, "restored", parsed.Accounts[0].Label)
+	require.Equal(t, treasury.Owner, parsed.Accounts[0].Owner)
+}
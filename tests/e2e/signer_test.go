@@ -0,0 +1,199 @@
+package e2e_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/2Finance-Labs/go-client-2finance/client_2finance/client_2financetest"
+	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/walletV1"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+)
+
+// memoryToken is a PKCS11Token holding its key in memory.
+type memoryToken struct {
+	privateKey ed25519.PrivateKey
+}
+
+func (m memoryToken) PublicKey() (ed25519.PublicKey, error) {
+	return m.privateKey.Public().(ed25519.PublicKey), nil
+}
+
+func (m memoryToken) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(m.privateKey, message), nil
+}
+
+func Test_RemoteSigner_UnixSocket(t *testing.T) {
+	daemonWallet := setupSignerWallet(t)
+
+	socketDir, err := os.MkdirTemp("", "signer")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(socketDir) })
+	socketPath := filepath.Join(socketDir, "signer.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	server := &http.Server{Handler: wallet_manager.RemoteSignerHandler(daemonWallet.Wallet)}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	remote, err := wallet_manager.NewRemoteSigner("unix://" + socketPath)
+	if err != nil {
+		t.Fatalf("NewRemoteSigner: %v", err)
+	}
+	assert.Equal(t, daemonWallet.PublicKey, remote.GetPublicKey())
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient(client2f.WithTransactionSigner(remote))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	createWallet(t, c, daemonWallet.PublicKey)
+
+	txs := node.Transactions()
+	require.Len(t, txs, 2)
+	for _, tx := range txs {
+		assert.Equal(t, daemonWallet.PublicKey, tx.From)
+		assert.NotEmpty(t, tx.Signature)
+	}
+
+	_, err = remote.SignTransaction(client2f.ChainIDTestnet, setupSignerWallet(t).PublicKey, "", "m", nil, 1, "uuid")
+	require.Error(t, err, "a signer must refuse to sign for another account")
+
+	m, err := wallet_manager.NewMultisigTransaction(client2f.ChainIDTestnet, setupSignerWallet(t).PublicKey, "", "m", nil, 1, "uuid", 1, []string{daemonWallet.PublicKey})
+	require.NoError(t, err)
+	require.NoError(t, m.Approve(remote), "approvals are signed through the daemon")
+	require.NoError(t, m.CheckThreshold())
+}
+
+func Test_RemoteSigner_MultisigOnly(t *testing.T) {
+	daemonWallet := setupSignerWallet(t)
+	officers := []e2eSigner{setupSignerWallet(t), setupSignerWallet(t)}
+
+	server := httptest.NewServer(wallet_manager.RemoteSignerHandler(daemonWallet.Wallet, wallet_manager.WithMultisigOnly()))
+	t.Cleanup(server.Close)
+
+	remote, err := wallet_manager.NewRemoteSigner(server.URL)
+	if err != nil {
+		t.Fatalf("NewRemoteSigner: %v", err)
+	}
+
+	_, err = remote.SignTransaction(client2f.ChainIDTestnet, daemonWallet.PublicKey, "", "m", nil, 1, "uuid")
+	require.ErrorContains(t, err, "only signs approved multisig transactions")
+
+	m, err := wallet_manager.NewMultisigTransaction(client2f.ChainIDTestnet, daemonWallet.PublicKey, "", "m", nil, 1, "uuid", 2,
+		[]string{officers[0].PublicKey, officers[1].PublicKey})
+	require.NoError(t, err)
+	require.NoError(t, m.Approve(officers[0].Wallet))
+
+	// The daemon checks the threshold itself: a client skipping the check,
+	// or forging an approval, gets nothing signed.
+	_, err = remote.SignMultisigTransaction(m)
+	require.ErrorContains(t, err, "threshold")
+
+	forged := *m
+	forged.Approvals = append(slices.Clone(m.Approvals), wallet_manager.MultisigApproval{
+		Signer:    officers[1].PublicKey,
+		Signature: m.Approvals[0].Signature,
+	})
+	_, err = remote.SignMultisigTransaction(&forged)
+	require.ErrorContains(t, err, "threshold")
+
+	require.NoError(t, m.Approve(officers[1].Wallet))
+	signed, err := remote.SignMultisigTransaction(m)
+	require.NoError(t, err)
+	assert.Equal(t, daemonWallet.PublicKey, signed.From)
+	assert.NotEmpty(t, signed.Signature)
+
+	// Approvals are signed over the multisig envelope only, never over bytes
+	// chosen by the caller.
+	resp, err := http.Post(server.URL+"/v1/sign-approval", "application/json", strings.NewReader(`{"message": "aGVsbG8="}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	_, err = remote.SignApproval(m)
+	require.ErrorContains(t, err, "not a signer", "the daemon is not one of the approvers")
+}
+
+func Test_RemoteSigner_RejectsTamperedTransaction(t *testing.T) {
+	daemonWallet := setupSignerWallet(t)
+	handler := wallet_manager.RemoteSignerHandler(daemonWallet.Wallet)
+
+	// A daemon that signs a different transaction than the one requested.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			signed, err := daemonWallet.Wallet.SignTransaction(client2f.ChainIDTestnet, daemonWallet.PublicKey, "", "Other", nil, 1, "uuid")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"transaction": signed})
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	remote, err := wallet_manager.NewRemoteSigner(server.URL)
+	if err != nil {
+		t.Fatalf("NewRemoteSigner: %v", err)
+	}
+
+	_, err = remote.SignTransaction(client2f.ChainIDTestnet, daemonWallet.PublicKey, "", "Transfer", nil, 1, "uuid")
+	require.Error(t, err)
+
+	_, err = wallet_manager.NewRemoteSigner("ftp://example.com")
+	require.Error(t, err)
+}
+
+func Test_PKCS11Signer_SignsThroughToken(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tokenSigner, err := wallet_manager.NewPKCS11Signer(memoryToken{privateKey: privateKey}, nil)
+	if err != nil {
+		t.Fatalf("NewPKCS11Signer: %v", err)
+	}
+
+	node := client_2financetest.NewNode()
+	c, err := node.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	asToken := c.WithContext(client2f.WithSigner(context.Background(), tokenSigner))
+	createWallet(t, asToken, tokenSigner.GetPublicKey())
+
+	txs := node.Transactions()
+	require.Len(t, txs, 2)
+	assert.Equal(t, tokenSigner.GetPublicKey(), txs[1].From)
+
+	// The token signs the network digest: Ed25519 being deterministic, the
+	// transaction is the one SignTransactionHexKey makes with the same key.
+	unsigned := txs[1]
+	unsigned.Hash, unsigned.Signature = "", ""
+	want, err := transaction.SignTransactionHexKey(hex.EncodeToString(privateKey), &unsigned)
+	require.NoError(t, err)
+	assert.Equal(t, want.Hash, txs[1].Hash)
+	assert.Equal(t, want.Signature, txs[1].Signature)
+
+	// Without a signer the client refuses to send.
+	_, err = c.DeployContract1(walletV1.WALLET_CONTRACT_V1)
+	require.ErrorIs(t, err, client2f.ErrValidation)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
@@ -525,6 +526,10 @@ func TestTokenFlowFungible(t *testing.T) {
 	require.Error(t, err)
 	assert.ErrorContains(t, err, "insufficient balance")
 
+	var contractErr *client2f.ContractError
+	require.ErrorAs(t, err, &contractErr)
+	assert.Equal(t, tokenV1.METHOD_BURN_TOKEN, contractErr.Method, "contract error method mismatch")
+
 	burnAmount = "1500"
 	burnToken, err = c.BurnToken(tok.Address, burnAmount, []string{})
 	if err != nil {
@@ -0,0 +1,174 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/2finance/2finance-network/infra/event"
+	"gitlab.com/2finance/2finance-network/infra/mqtt"
+)
+
+// memoryBroker is an in-memory MQTT broker with single-level wildcards.
+type memoryBroker struct {
+	mqtt.IMQTT
+
+	mu       sync.Mutex
+	handlers map[string][]func(mqtt.Client, mqtt.Message)
+}
+
+type memoryMessage struct {
+	mqtt.Message
+
+	topic   string
+	payload []byte
+}
+
+func (m memoryMessage) Topic() string   { return m.topic }
+func (m memoryMessage) Payload() []byte { return m.payload }
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{handlers: make(map[string][]func(mqtt.Client, mqtt.Message))}
+}
+
+func (b *memoryBroker) SubscribeWithHandler(topic string, handler func(mqtt.Client, mqtt.Message)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[topic] = append(b.handlers[topic], handler)
+	return nil
+}
+
+func (b *memoryBroker) Publish(topic string, data []byte) error {
+	b.mu.Lock()
+	var matched []func(mqtt.Client, mqtt.Message)
+	for filter, handlers := range b.handlers {
+		if topicMatches(filter, topic) {
+			matched = append(matched, handlers...)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, handler := range matched {
+		go handler(nil, memoryMessage{topic: topic, payload: data})
+	}
+	return nil
+}
+
+func (b *memoryBroker) subscriptions() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.handlers)
+}
+
+func topicMatches(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	if len(filterLevels) != len(topicLevels) {
+		return false
+	}
+	for i := range filterLevels {
+		if filterLevels[i] != "+" && filterLevels[i] != topicLevels[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// echoNode subscribes to requests the way the node does, one level below the
+// request topic, and answers each on the response topic with the same level,
+// echoing the request params after a delay.
+func echoNode(t *testing.T, broker *memoryBroker, delay func(params string) time.Duration) {
+	t.Helper()
+
+	base := strings.TrimSuffix(event.TRANSACTIONS_REQUEST_TOPIC, "/+")
+	err := broker.SubscribeWithHandler(event.TRANSACTIONS_REQUEST_TOPIC, func(_ mqtt.Client, msg mqtt.Message) {
+		replyTo := strings.TrimPrefix(msg.Topic(), base+"/")
+
+		var request event.RequestPayload
+		if err := json.Unmarshal(msg.Payload(), &request); err != nil {
+			t.Errorf("unmarshal request: %v", err)
+			return
+		}
+
+		params, _ := request.Params.(string)
+		time.Sleep(delay(params))
+
+		data, err := json.Marshal(event.ResponsePayload{Status: "success", Data: params})
+		if err != nil {
+			t.Errorf("marshal response: %v", err)
+			return
+		}
+		_ = broker.Publish(fmt.Sprintf("%s/%s", event.TRANSACTIONS_RESPONSE_TOPIC, replyTo), data)
+	})
+	require.NoError(t, err)
+}
+
+func Test_MQTTTransport_OneLevelReplyTopics(t *testing.T) {
+	broker := newMemoryBroker()
+	echoNode(t, broker, func(params string) time.Duration {
+		if params == "slow" {
+			return 200 * time.Millisecond
+		}
+		return time.Duration(len(params)%5) * time.Millisecond
+	})
+
+	transport := client2f.NewMQTTTransport(broker)
+
+	const workers = 16
+
+	// Concurrent callers under one replyTo each get their own response.
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(params string) {
+			defer wg.Done()
+
+			resp, err := transport.RoundTrip(context.Background(), "client", event.RequestPayload{Method: "echo", Params: params})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if resp.Data != params {
+				errs <- fmt.Errorf("request %q got the response %v of another caller", params, resp.Data)
+			}
+		}(strings.Repeat("x", i+1))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// Answered slots are reused rather than subscribed again.
+	subscribed := broker.subscriptions()
+	assert.LessOrEqual(t, subscribed, workers+1, "node subscription and one slot per caller in flight")
+	for i := 0; i < workers; i++ {
+		_, err := transport.RoundTrip(context.Background(), "client", event.RequestPayload{Method: "echo", Params: "again"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, subscribed, broker.subscriptions(), "sequential requests reuse a slot")
+
+	// The late response of an abandoned request never reaches a later one.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := transport.RoundTrip(ctx, "client", event.RequestPayload{Method: "echo", Params: "slow"})
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+
+	for i := 0; i < 10; i++ {
+		resp, err := transport.RoundTrip(context.Background(), "client", event.RequestPayload{Method: "echo", Params: "fast"})
+		require.NoError(t, err)
+		require.Equal(t, "fast", resp.Data)
+		time.Sleep(25 * time.Millisecond)
+	}
+}
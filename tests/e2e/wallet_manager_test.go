@@ -1,12 +1,23 @@
 package e2e_test
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/2Finance-Labs/go-client-2finance/wallet_manager"
+	"github.com/google/tink/go/keyset"
 	"github.com/stretchr/testify/require"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/dropV1"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/raffleV1"
+	"gitlab.com/2finance/2finance-network/blockchain/contract/tokenV1"
 )
 
 func TestWalletManagerE2E_LockUnlockRealFlow(t *testing.T) {
@@ -310,6 +321,29 @@ func TestWalletManagerE2E_RotatePassword(t *testing.T) {
 	keyAfterRotation, err := manager.GetPrivateKey("SignTransaction", "")
 	require.NoError(t, err)
 	require.Equal(t, originalPrivateKeyBytes, keyAfterRotation)
+
+	// -------------------------
+	// ASSERT: ONLY BACKUPS UNDER THE RETIRED PASSWORD ARE REMOVED
+	// -------------------------
+	backups, err := wallet_manager.NewEncryptionFile(walletPath).Backups()
+	require.NoError(t, err)
+	require.Empty(t, backups, "the replaced file opened with the old password")
+
+	otherPath := filepath.Join(walletDir, "other.wallet")
+	other := wallet_manager.NewWalletManager(otherPath)
+	_, otherPrivateKey, err := other.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+	require.NoError(t, other.ImportWallet([]byte(otherPrivateKey), "OtherPassword123!"))
+
+	otherBytes, err := os.ReadFile(otherPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(walletPath+".bak.3", otherBytes, 0600))
+
+	require.NoError(t, manager.RotatePassword(newPassword, currentPassword))
+
+	backups, err = wallet_manager.NewEncryptionFile(walletPath).Backups()
+	require.NoError(t, err)
+	require.Equal(t, []string{walletPath + ".bak.3"}, backups, "a backup under another password is kept")
 }
 
 func TestWalletManagerE2E_RotatePasswordInvalidInputs(t *testing.T) {
@@ -341,4 +375,582 @@ func TestWalletManagerE2E_RotatePasswordInvalidInputs(t *testing.T) {
 	err = manager.RotatePassword("WrongPassword123!", newPassword)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "failed to decrypt wallet file with current password")
-}
\ No newline at end of file
+}
+
+func TestWalletManagerE2E_SignTransactionPolicy(t *testing.T) {
+	// -------------------------
+	// ARRANGE
+	// -------------------------
+	password := "StrongPassword123!"
+	method := dropV1.METHOD_WITHDRAW_DROP
+
+	walletPath := filepath.Join(t.TempDir(), "policy.wallet")
+	manager := wallet_manager.NewWalletManager(walletPath)
+
+	publicKey, privateKey, err := manager.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+	require.NoError(t, manager.ImportWallet([]byte(privateKey), password))
+
+	// -------------------------
+	// ASSERT: ONLY WALLET OPERATIONS NEED THE PASSWORD BY DEFAULT
+	// -------------------------
+	require.True(t, manager.RequiresPassword("ExportPrivateKey"))
+	require.False(t, manager.RequiresPassword(dropV1.METHOD_WITHDRAW_DROP))
+	require.False(t, manager.RequiresPassword(raffleV1.METHOD_WITHDRAW_RAFFLE))
+	require.False(t, manager.RequiresPassword(tokenV1.METHOD_REVOKE_MINT_AUTHORITY))
+
+	// -------------------------
+	// ASSERT: LOCKED WALLET IS REFUSED WITH A TYPED ERROR
+	// -------------------------
+	_, err = manager.SignTransaction(2, publicKey, publicKey, method, nil, 1, "uuid")
+	require.ErrorIs(t, err, wallet_manager.ErrWalletLocked)
+
+	var lockedErr *wallet_manager.WalletLockedError
+	require.ErrorAs(t, err, &lockedErr)
+	require.Equal(t, publicKey, lockedErr.Owner)
+	require.Equal(t, method, lockedErr.Method)
+
+	// -------------------------
+	// ACT & ASSERT: UNLOCKED WALLET SIGNS
+	// -------------------------
+	require.NoError(t, manager.Unlock(password))
+
+	signed, err := manager.SignTransaction(2, publicKey, publicKey, method, nil, 1, "uuid")
+	require.NoError(t, err)
+	require.NotEmpty(t, signed.Signature)
+
+	// -------------------------
+	// ACT & ASSERT: PER-METHOD PASSWORD
+	// -------------------------
+	manager.SetPasswordRequired(method, true)
+	require.True(t, manager.RequiresPassword(method))
+
+	_, err = manager.SignTransaction(2, publicKey, publicKey, method, nil, 1, "uuid")
+	require.ErrorIs(t, err, wallet_manager.ErrPasswordRequired)
+
+	_, err = manager.SignTransaction(2, publicKey, publicKey, tokenV1.METHOD_TRANSFER_TOKEN, nil, 1, "uuid")
+	require.NoError(t, err, "other methods keep signing without the password")
+
+	_, err = manager.SignTransactionWithPassword("WrongPassword123!", 2, publicKey, publicKey, method, nil, 1, "uuid")
+	require.Error(t, err)
+
+	require.NoError(t, manager.Lock())
+	signed, err = manager.SignTransactionWithPassword(password, 2, publicKey, publicKey, method, nil, 1, "uuid")
+	require.NoError(t, err, "the password signs on a locked wallet")
+	require.NotEmpty(t, signed.Signature)
+	require.False(t, manager.IsUnlocked(), "signing with the password does not unlock the wallet")
+
+	// -------------------------
+	// ACT & ASSERT: THE PASSWORD LEAVES THE SESSION AS IT WAS
+	// -------------------------
+	require.NoError(t, manager.SetUnlockPolicy(wallet_manager.UnlockPolicy{TTL: time.Hour, MaxSignatures: 2}))
+	require.NoError(t, manager.Unlock(password))
+	until := manager.UnlockedUntil()
+
+	_, err = manager.SignTransaction(2, publicKey, publicKey, tokenV1.METHOD_TRANSFER_TOKEN, nil, 1, "uuid")
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	_, err = manager.SignTransactionWithPassword(password, 2, publicKey, publicKey, method, nil, 1, "uuid")
+	require.NoError(t, err)
+	require.Equal(t, until, manager.UnlockedUntil(), "the session TTL is not restarted")
+
+	_, err = manager.SignTransaction(2, publicKey, publicKey, tokenV1.METHOD_TRANSFER_TOKEN, nil, 1, "uuid")
+	require.NoError(t, err)
+	require.False(t, manager.IsUnlocked(), "the session budget is not reset")
+
+	manager.SetPasswordRequired(method, false)
+	require.NoError(t, manager.Unlock(password))
+	_, err = manager.SignTransaction(2, publicKey, publicKey, method, nil, 1, "uuid")
+	require.NoError(t, err)
+}
+
+func TestWalletManagerE2E_UnlockPolicies(t *testing.T) {
+	// -------------------------
+	// ARRANGE
+	// -------------------------
+	password := "StrongPassword123!"
+	method := tokenV1.METHOD_TRANSFER_TOKEN
+
+	walletPath := filepath.Join(t.TempDir(), "unlock.wallet")
+	manager := wallet_manager.NewWalletManager(walletPath)
+
+	publicKey, privateKey, err := manager.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+	require.NoError(t, manager.ImportWallet([]byte(privateKey), password))
+
+	locks := make(chan wallet_manager.LockReason, 8)
+	unlocks := make(chan time.Time, 8)
+	manager.OnLock(func(reason wallet_manager.LockReason) { locks <- reason })
+	manager.OnUnlock(func(until time.Time) { unlocks <- until })
+
+	sign := func() error {
+		_, err := manager.SignTransaction(2, publicKey, publicKey, method, nil, 1, "uuid")
+		return err
+	}
+
+	// -------------------------
+	// ASSERT: DEFAULT FIXED TTL
+	// -------------------------
+	require.True(t, manager.UnlockedUntil().IsZero(), "locked wallet has no deadline")
+
+	require.NoError(t, manager.Unlock(password))
+	until := <-unlocks
+	require.Equal(t, until, manager.UnlockedUntil())
+	require.WithinDuration(t, time.Now().Add(2*time.Minute), until, 5*time.Second)
+
+	require.NoError(t, sign())
+	require.Equal(t, until, manager.UnlockedUntil(), "fixed TTL does not slide")
+
+	require.NoError(t, manager.Lock())
+	require.Equal(t, wallet_manager.LockReasonExplicit, <-locks)
+	require.True(t, manager.UnlockedUntil().IsZero())
+
+	// -------------------------
+	// ACT & ASSERT: SIGNATURE BUDGET
+	// -------------------------
+	require.NoError(t, manager.SetUnlockPolicy(wallet_manager.SignatureBudgetUnlock(2)))
+	require.NoError(t, manager.Unlock(password))
+	require.True(t, (<-unlocks).IsZero(), "budget-only policy has no deadline")
+	require.True(t, manager.IsUnlocked())
+
+	require.NoError(t, sign())
+	require.NoError(t, sign())
+	require.Equal(t, wallet_manager.LockReasonSignatureBudget, <-locks)
+	require.False(t, manager.IsUnlocked())
+	require.ErrorIs(t, sign(), wallet_manager.ErrWalletLocked)
+
+	// -------------------------
+	// ACT & ASSERT: SLIDING TTL
+	// -------------------------
+	ttl := 300 * time.Millisecond
+	require.NoError(t, manager.SetUnlockPolicy(wallet_manager.SlidingUnlock(ttl)))
+	require.NoError(t, manager.Unlock(password))
+	first := <-unlocks
+
+	for i := 0; i < 3; i++ {
+		time.Sleep(ttl / 2)
+		require.NoError(t, sign(), "each signature keeps the wallet unlocked")
+	}
+	require.True(t, manager.UnlockedUntil().After(first), "signatures slide the deadline")
+
+	select {
+	case reason := <-locks:
+		require.Equal(t, wallet_manager.LockReasonExpired, reason)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("sliding unlock did not expire")
+	}
+	require.False(t, manager.IsUnlocked())
+
+	// -------------------------
+	// ACT & ASSERT: UNTIL EXPLICIT LOCK
+	// -------------------------
+	require.NoError(t, manager.SetUnlockPolicy(wallet_manager.UnlockUntilLock()))
+	require.NoError(t, manager.Unlock(password))
+	<-unlocks
+	require.True(t, manager.IsUnlocked())
+	require.True(t, manager.UnlockedUntil().IsZero())
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, sign())
+	}
+	require.NoError(t, manager.Lock())
+	require.Equal(t, wallet_manager.LockReasonExplicit, <-locks)
+
+	// -------------------------
+	// ASSERT: INVALID POLICIES
+	// -------------------------
+	require.Error(t, manager.SetUnlockPolicy(wallet_manager.UnlockPolicy{TTL: -time.Second}))
+	require.Error(t, manager.SetUnlockPolicy(wallet_manager.UnlockPolicy{Sliding: true}))
+	require.Error(t, manager.SetUnlockPolicy(wallet_manager.SignatureBudgetUnlock(-1)))
+}
+
+func TestWalletManagerE2E_MigratesVersion1WalletFile(t *testing.T) {
+	// -------------------------
+	// ARRANGE: A WALLET WRITTEN BY THE VERSION 1 FORMAT
+	// -------------------------
+	password := "StrongPassword123!"
+
+	walletDir := t.TempDir()
+	walletPath := filepath.Join(walletDir, "legacy.wallet")
+	manager := wallet_manager.NewWalletManager(walletPath)
+
+	publicKey, privateKey, err := manager.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+
+	writeVersion1WalletFile(t, walletPath, publicKey, privateKey, password)
+	legacyBytes, err := os.ReadFile(walletPath)
+	require.NoError(t, err)
+
+	// -------------------------
+	// ACT: UNLOCK MIGRATES
+	// -------------------------
+	_, err = manager.Migrate("WrongPassword123!")
+	require.Error(t, err)
+
+	require.NoError(t, manager.Unlock(password))
+	require.Equal(t, publicKey, manager.GetPublicKey())
+
+	signed, err := manager.SignTransaction(2, publicKey, publicKey, tokenV1.METHOD_TRANSFER_TOKEN, nil, 1, "uuid")
+	require.NoError(t, err)
+	require.NotEmpty(t, signed.Signature)
+
+	// -------------------------
+	// ASSERT: BACKUP AND UPGRADED FILE
+	// -------------------------
+	backups, err := filepath.Glob(walletPath + ".v1-*.bak")
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	backupBytes, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	require.Equal(t, legacyBytes, backupBytes, "backup must be the untouched version 1 file")
+
+	entries, err := os.ReadDir(walletDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.NotContains(t, entry.Name(), ".tmp-", "no temporary file may be left behind")
+	}
+
+	encryptionFile := wallet_manager.NewEncryptionFile(walletPath)
+	localFile, err := encryptionFile.Read()
+	require.NoError(t, err)
+	require.Equal(t, uint32(128*1024), localFile.KDF.MemoryKB)
+
+	payload, err := encryptionFile.Decrypt(*localFile, password)
+	require.NoError(t, err)
+
+	var walletFile wallet_manager.WalletFile
+	require.NoError(t, json.Unmarshal(payload, &walletFile))
+	require.Equal(t, 2, walletFile.Version)
+	require.Equal(t, "ed25519", walletFile.KeyType)
+	require.Equal(t, uint32(128*1024), walletFile.WrappedKeyset.KDF.MemoryKB)
+	require.Len(t, walletFile.Migrations, 1)
+	require.Equal(t, 1, walletFile.Migrations[0].FromVersion)
+	require.Equal(t, 2, walletFile.Migrations[0].ToVersion)
+	require.Equal(t, filepath.Base(backups[0]), walletFile.Migrations[0].BackupFile)
+
+	// -------------------------
+	// ASSERT: CURRENT FILES ARE LEFT ALONE
+	// -------------------------
+	migrated, err := manager.Migrate(password)
+	require.NoError(t, err)
+	require.False(t, migrated)
+
+	reopened := wallet_manager.NewWalletManager(walletPath)
+	require.NoError(t, reopened.Unlock(password))
+	require.Equal(t, publicKey, reopened.GetPublicKey())
+
+	backups, err = filepath.Glob(walletPath + ".v1-*.bak")
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	// -------------------------
+	// ASSERT: ROTATING THE PASSWORD DROPS THE BACKUP UNDER THE OLD ONE
+	// -------------------------
+	newPassword := "NewStrongPassword123!"
+	require.NoError(t, reopened.RotatePassword(password, newPassword))
+
+	backups, err = filepath.Glob(walletPath + ".v1-*.bak")
+	require.NoError(t, err)
+	require.Empty(t, backups)
+
+	localFile, err = encryptionFile.Read()
+	require.NoError(t, err)
+	payload, err = encryptionFile.Decrypt(*localFile, newPassword)
+	require.NoError(t, err)
+
+	walletFile = wallet_manager.WalletFile{}
+	require.NoError(t, json.Unmarshal(payload, &walletFile))
+	require.Len(t, walletFile.Migrations, 1)
+	require.Empty(t, walletFile.Migrations[0].BackupFile)
+}
+
+// writeVersion1WalletFile writes privateKey the way version 1 wallets were
+// written: argon2id with 64 MiB and one thread for both the file and the
+// Tink keyset.
+func writeVersion1WalletFile(t *testing.T, walletPath, publicKey, privateKey, password string) {
+	t.Helper()
+
+	version1KDF := func() wallet_manager.KeysetKDFParams {
+		salt := make([]byte, 16)
+		_, err := rand.Read(salt)
+		require.NoError(t, err)
+
+		return wallet_manager.KeysetKDFParams{
+			Alg:      "argon2id",
+			Time:     3,
+			MemoryKB: 64 * 1024,
+			Parallel: 1,
+			KeyLen:   32,
+			Salt:     salt,
+		}
+	}
+
+	encryptionKey := wallet_manager.NewEncryption(publicKey)
+	kh, err := encryptionKey.NewAEAD()
+	require.NoError(t, err)
+
+	encryptedPrivateKey, err := encryptionKey.EncryptPrivateKey([]byte(privateKey))
+	require.NoError(t, err)
+
+	keysetKDF := version1KDF()
+	keysetAEAD, err := wallet_manager.NewPasswordAEAD(password, keysetKDF)
+	require.NoError(t, err)
+
+	var keysetJSON bytes.Buffer
+	require.NoError(t, kh.Write(keyset.NewJSONWriter(&keysetJSON), keysetAEAD))
+
+	now := time.Now()
+	payload, err := json.Marshal(wallet_manager.WalletFile{
+		Version:             1,
+		Owner:               publicKey,
+		EncryptedPrivateKey: encryptedPrivateKey,
+		WrappedKeyset: wallet_manager.WrappedTinkKeyset{
+			KDF:                 keysetKDF,
+			EncryptedKeysetJSON: keysetJSON.Bytes(),
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	require.NoError(t, err)
+
+	fileKDF := version1KDF()
+	fileAEAD, err := wallet_manager.NewPasswordAEAD(password, fileKDF)
+	require.NoError(t, err)
+
+	cipher, err := fileAEAD.Encrypt(payload, []byte("wallet-manager-file:v1"))
+	require.NoError(t, err)
+
+	require.NoError(t, wallet_manager.NewEncryptionFile(walletPath).Write(wallet_manager.LocalEncryptedWalletFile{
+		KDF:    fileKDF,
+		Cipher: cipher,
+	}))
+}
+
+func TestWalletManagerE2E_RollingBackupsAndFileLock(t *testing.T) {
+	// -------------------------
+	// ARRANGE
+	// -------------------------
+	firstPassword := "StrongPassword123!"
+	walletPath := filepath.Join(t.TempDir(), "backups.wallet")
+	manager := wallet_manager.NewWalletManager(walletPath)
+	encryptionFile := wallet_manager.NewEncryptionFile(walletPath)
+
+	var publicKeys []string
+	for i := 0; i < 5; i++ {
+		publicKey, privateKey, err := manager.GenerateEd25519KeyPairHex()
+		require.NoError(t, err)
+		require.NoError(t, manager.ImportWallet([]byte(privateKey), firstPassword))
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	// -------------------------
+	// ASSERT: THE LAST THREE FILES ARE KEPT, NEWEST FIRST
+	// -------------------------
+	backups, err := encryptionFile.Backups()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		walletPath + ".bak.1",
+		walletPath + ".bak.2",
+		walletPath + ".bak.3",
+	}, backups)
+
+	for i, backup := range backups {
+		restored := wallet_manager.NewWalletManager(backup)
+		require.NoError(t, restored.Unlock(firstPassword), "backups stay encrypted wallet files")
+		require.Equal(t, publicKeys[len(publicKeys)-2-i], restored.GetPublicKey())
+		require.NoError(t, restored.Lock())
+	}
+
+	// -------------------------
+	// ACT & ASSERT: TWO PROCESSES ROTATING AT ONCE
+	// -------------------------
+	// Each manager stands for a separate process: only the file lock orders
+	// them, so exactly one rotation can see the password it started from.
+	rotators := []wallet_manager.IWalletManager{
+		wallet_manager.NewWalletManager(walletPath),
+		wallet_manager.NewWalletManager(walletPath),
+	}
+	for _, rotator := range rotators {
+		require.NoError(t, rotator.Unlock(firstPassword))
+		require.NoError(t, rotator.Lock())
+	}
+
+	results := make(chan error, len(rotators))
+	for i, rotator := range rotators {
+		go func(rotator wallet_manager.IWalletManager, newPassword string) {
+			results <- rotator.RotatePassword(firstPassword, newPassword)
+		}(rotator, fmt.Sprintf("RotatedPassword%d!", i))
+	}
+
+	var succeeded int
+	for range rotators {
+		if <-results == nil {
+			succeeded++
+		}
+	}
+	require.Equal(t, 1, succeeded)
+
+	// -------------------------
+	// ASSERT: ROTATION DROPS BACKUPS UNDER THE OLD PASSWORD
+	// -------------------------
+	backups, err = encryptionFile.Backups()
+	require.NoError(t, err)
+	require.Empty(t, backups)
+
+	require.Error(t, wallet_manager.NewWalletManager(walletPath).Unlock(firstPassword))
+}
+
+func TestWalletManagerE2E_ShamirSharesRecovery(t *testing.T) {
+	// -------------------------
+	// ARRANGE
+	// -------------------------
+	password := "StrongPassword123!"
+	recoveredPassword := "RecoveredPassword123!"
+
+	manager := wallet_manager.NewWalletManager(filepath.Join(t.TempDir(), "treasury.wallet"))
+	publicKey, privateKey, err := manager.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+	require.NoError(t, manager.ImportWallet([]byte(privateKey), password))
+
+	custodians := []wallet_manager.ShareCustodian{
+		{Name: "alice", Password: "AlicePassword123!"},
+		{Name: "bob", Password: "BobPassword123!"},
+		{Name: "vault"},
+	}
+
+	// -------------------------
+	// ASSERT: INVALID SPLITS
+	// -------------------------
+	_, err = manager.SplitShares("WrongPassword123!", 2, custodians)
+	require.Error(t, err)
+
+	_, err = manager.SplitShares(password, 1, custodians)
+	require.Error(t, err)
+
+	_, err = manager.SplitShares(password, 4, custodians)
+	require.Error(t, err)
+
+	// -------------------------
+	// ACT: SPLIT 2-OF-3
+	// -------------------------
+	shares, err := manager.SplitShares(password, 2, custodians)
+	require.NoError(t, err)
+	require.Len(t, shares, 3)
+	require.False(t, manager.IsUnlocked(), "splitting does not unlock the wallet")
+
+	for i, data := range shares {
+		require.NotContains(t, string(data), privateKey)
+
+		share, err := wallet_manager.ParseWalletShare(data)
+		require.NoError(t, err)
+		require.Equal(t, publicKey, share.Owner)
+		require.Equal(t, 2, share.Threshold)
+		require.Equal(t, 3, share.Total)
+		require.Equal(t, i+1, share.Index)
+		require.Equal(t, custodians[i].Name, share.Custodian)
+		require.Equal(t, custodians[i].Password != "", share.KDF != nil)
+	}
+
+	alice := wallet_manager.RecoveryShare{Data: shares[0], Password: custodians[0].Password}
+	bob := wallet_manager.RecoveryShare{Data: shares[1], Password: custodians[1].Password}
+	vault := wallet_manager.RecoveryShare{Data: shares[2]}
+
+	// -------------------------
+	// ACT & ASSERT: ANY TWO SHARES RECOVER
+	// -------------------------
+	for _, pair := range [][]wallet_manager.RecoveryShare{{alice, vault}, {bob, vault}, {bob, alice}} {
+		recovered := wallet_manager.NewWalletManager(filepath.Join(t.TempDir(), "recovered.wallet"))
+		require.NoError(t, recovered.RecoverFromShares(pair, recoveredPassword))
+		require.NoError(t, recovered.Unlock(recoveredPassword))
+		require.Equal(t, publicKey, recovered.GetPublicKey())
+	}
+
+	// -------------------------
+	// ASSERT: FAILED RECOVERIES
+	// -------------------------
+	targetPath := filepath.Join(t.TempDir(), "failed.wallet")
+	target := wallet_manager.NewWalletManager(targetPath)
+
+	err = target.RecoverFromShares([]wallet_manager.RecoveryShare{alice}, recoveredPassword)
+	require.ErrorContains(t, err, "1 of the 2")
+
+	err = target.RecoverFromShares([]wallet_manager.RecoveryShare{{Data: shares[0]}, vault}, recoveredPassword)
+	require.True(t, errors.Is(err, wallet_manager.ErrPasswordRequired))
+
+	err = target.RecoverFromShares([]wallet_manager.RecoveryShare{{Data: shares[0], Password: custodians[1].Password}, vault}, recoveredPassword)
+	require.Error(t, err)
+
+	err = target.RecoverFromShares([]wallet_manager.RecoveryShare{vault, vault}, recoveredPassword)
+	require.ErrorContains(t, err, "given twice")
+
+	otherSplit, err := manager.SplitShares(password, 2, custodians)
+	require.NoError(t, err)
+	err = target.RecoverFromShares([]wallet_manager.RecoveryShare{alice, {Data: otherSplit[2]}}, recoveredPassword)
+	require.ErrorContains(t, err, "another split")
+
+	tampered := strings.Replace(string(shares[2]), `"custodian": "vault"`, `"custodian": "mallory"`, 1)
+	_, err = wallet_manager.ParseWalletShare([]byte(tampered))
+	require.ErrorContains(t, err, "checksum")
+
+	require.Contains(t, string(shares[0]), `"memory_kb": 131072`)
+	crafted := strings.Replace(string(shares[0]), `"memory_kb": 131072`, `"memory_kb": 4194304`, 1)
+	err = target.RecoverFromShares([]wallet_manager.RecoveryShare{{Data: []byte(crafted), Password: custodians[0].Password}, vault}, recoveredPassword)
+	require.ErrorContains(t, err, "KDF", "a crafted share must not choose the cost of the key derivation")
+
+	_, err = os.Stat(targetPath)
+	require.True(t, os.IsNotExist(err), "failed recoveries write no wallet file")
+}
+
+func TestWalletManagerE2E_RejectsInvalidKDFParams(t *testing.T) {
+	// -------------------------
+	// ARRANGE
+	// -------------------------
+	password := "StrongPassword123!"
+	walletPath := filepath.Join(t.TempDir(), "kdf.wallet")
+	manager := wallet_manager.NewWalletManager(walletPath)
+	encryptionFile := wallet_manager.NewEncryptionFile(walletPath)
+
+	_, privateKey, err := manager.GenerateEd25519KeyPairHex()
+	require.NoError(t, err)
+	require.NoError(t, manager.ImportWallet([]byte(privateKey), password))
+
+	original, err := os.ReadFile(walletPath)
+	require.NoError(t, err)
+
+	// -------------------------
+	// ACT & ASSERT: THE FILE KDF IS CHECKED BEFORE IT IS RUN
+	// -------------------------
+	localFile, err := encryptionFile.Read()
+	require.NoError(t, err)
+	localFile.KDF.MemoryKB = 1 << 30
+	data, err := json.Marshal(localFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(walletPath, data, 0600))
+
+	err = wallet_manager.NewWalletManager(walletPath).Unlock(password)
+	require.ErrorContains(t, err, "invalid wallet file")
+
+	// -------------------------
+	// ACT & ASSERT: SO IS THE KEYSET KDF
+	// -------------------------
+	require.NoError(t, os.WriteFile(walletPath, original, 0600))
+	localFile, err = encryptionFile.Read()
+	require.NoError(t, err)
+	payload, err := encryptionFile.Decrypt(*localFile, password)
+	require.NoError(t, err)
+
+	var walletFile wallet_manager.WalletFile
+	require.NoError(t, json.Unmarshal(payload, &walletFile))
+	walletFile.WrappedKeyset.KDF.Time = 0
+	payload, err = json.Marshal(walletFile)
+	require.NoError(t, err)
+
+	localFile, err = encryptionFile.Encrypt(payload, password)
+	require.NoError(t, err)
+	require.NoError(t, encryptionFile.Write(*localFile))
+
+	err = wallet_manager.NewWalletManager(walletPath).Unlock(password)
+	require.ErrorContains(t, err, "invalid wallet keyset")
+}
@@ -3,6 +3,7 @@ package e2e_test
 import (
 	"testing"
 
+	client2f "github.com/2Finance-Labs/go-client-2finance/client_2finance"
 	"github.com/stretchr/testify/assert"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/contractV1/domain"
 	"gitlab.com/2finance/2finance-network/blockchain/contract/walletV1"
@@ -135,4 +136,47 @@ func TestWalletWorkflow(t *testing.T) {
 	assert.Equal(t, walletState.Address, walletStateByPub.Address, "wallet state address mismatch between GetWalletByAddress and GetWalletByPublicKey")
 	assert.NotEmpty(t, walletStateByPub.CreatedAt, "wallet state CreatedAt should not be empty")
 	assert.NotEmpty(t, walletStateByPub.UpdatedAt, "wallet state UpdatedAt should not be empty")
-}
\ No newline at end of file
+}
+
+func TestWalletWorkflow_TypedDecoders(t *testing.T) {
+	wm := setupWalletManager(t)
+
+	pub, priv := genKey(t, wm)
+	importAndUnlockWallet(t, wm, pub, priv)
+
+	c := setupClient(t, wm)
+
+	contract, err := client2f.DecodeDeployedContract(c.DeployContract1(walletV1.WALLET_CONTRACT_V1))
+	if err != nil {
+		t.Fatalf("DecodeDeployedContract: %v", err)
+	}
+
+	if contract.Address == "" {
+		t.Fatalf("contract address empty")
+	}
+
+	wallet, err := client2f.DecodeWallet(c.AddWallet(contract.Address, pub))
+	if err != nil {
+		t.Fatalf("DecodeWallet: %v", err)
+	}
+
+	assert.Equal(t, pub, wallet.PublicKey, "wallet public key mismatch")
+
+	walletState, err := client2f.DecodeWalletState(c.GetWalletByAddress(wallet.Address))
+	if err != nil {
+		t.Fatalf("DecodeWalletState: %v", err)
+	}
+
+	assert.Equal(t, pub, walletState.PublicKey, "wallet state public key mismatch")
+	assert.Equal(t, wallet.Address, walletState.Address, "wallet state address mismatch")
+
+	// A decoder asked for a log type the output does not carry reports it
+	// instead of returning a zero value.
+	out, err := c.GetWalletByPublicKey(pub)
+	if err != nil {
+		t.Fatalf("GetWalletByPublicKey: %v", err)
+	}
+
+	_, err = client2f.DecodeEvent[walletDomain.Wallet](out, walletDomain.WALLET_CREATED_LOG)
+	assert.Error(t, err, "expected an error decoding a log from a state-only output")
+}
@@ -0,0 +1,258 @@
+package wallet_manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+)
+
+const (
+	// WalletBackupFormat identifies wallet backups in their format field.
+	WalletBackupFormat = "2finance-wallet-backup"
+
+	walletBackupVersion = 1
+)
+
+// WalletBackup is a portable, password-encrypted export of one or more
+// accounts. It is plain JSON and describes itself: the header lists the
+// accounts by label and public key and gives the KDF parameters, while the
+// private keys are sealed in Cipher. Checksum catches a damaged file before
+// the password is tried.
+type WalletBackup struct {
+	Format    string                `json:"format"`
+	Version   int                   `json:"version"`
+	CreatedAt time.Time             `json:"created_at"`
+	Accounts  []WalletBackupAccount `json:"accounts"`
+	KDF       KeysetKDFParams       `json:"kdf"`
+	Cipher    []byte                `json:"cipher"`
+	Checksum  string                `json:"checksum"`
+}
+
+// WalletBackupAccount is the public description of an account in a backup.
+type WalletBackupAccount struct {
+	Label          string `json:"label"`
+	Owner          string `json:"owner"`
+	DerivationPath string `json:"derivation_path,omitempty"`
+}
+
+// walletBackupSecret is one sealed account.
+type walletBackupSecret struct {
+	Owner      string `json:"owner"`
+	PrivateKey string `json:"private_key"`
+}
+
+// ParseWalletBackup decodes a backup and checks its format and checksum
+// without decrypting it, to see what it holds.
+func ParseWalletBackup(data []byte) (*WalletBackup, error) {
+	var backup WalletBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wallet backup: %w", err)
+	}
+
+	if backup.Format != WalletBackupFormat {
+		return nil, fmt.Errorf("not a wallet backup: format %q", backup.Format)
+	}
+
+	if backup.Version != walletBackupVersion {
+		return nil, fmt.Errorf("unsupported wallet backup version: %d", backup.Version)
+	}
+
+	if len(backup.Accounts) == 0 {
+		return nil, fmt.Errorf("wallet backup holds no account")
+	}
+
+	if err := validateKDFParams(backup.KDF); err != nil {
+		return nil, fmt.Errorf("invalid wallet backup: %w", err)
+	}
+
+	checksum, err := backup.checksum()
+	if err != nil {
+		return nil, err
+	}
+	if backup.Checksum != checksum {
+		return nil, errors.New("wallet backup checksum mismatch: the file is damaged")
+	}
+
+	return &backup, nil
+}
+
+// ExportBackup writes the wallet to a backup encrypted with backupPassword.
+// password must open the wallet: export is gated like ExportPrivateKey.
+func (w *WalletManager) ExportBackup(password, backupPassword string) ([]byte, error) {
+	privateKey, err := w.openPrivateKey(password)
+	if err != nil {
+		return nil, err
+	}
+	defer clearBytes(privateKey)
+
+	label := strings.TrimSuffix(filepath.Base(w.filePath), filepath.Ext(w.filePath))
+
+	return sealWalletBackup([]WalletBackupAccount{{Label: label, Owner: w.GetPublicKey()}}, [][]byte{privateKey}, backupPassword)
+}
+
+// ImportBackup imports the single account of backup into the wallet file,
+// encrypted with password like ImportWallet.
+func (w *WalletManager) ImportBackup(backup []byte, backupPassword, password string) error {
+	accounts, privateKeys, err := openWalletBackup(backup, backupPassword)
+	if err != nil {
+		return err
+	}
+	defer clearBackupKeys(privateKeys)
+
+	if len(accounts) != 1 {
+		return fmt.Errorf("wallet backup holds %d accounts: import it into a keystore", len(accounts))
+	}
+
+	return w.ImportWallet(privateKeys[0], password)
+}
+
+// sealWalletBackup encrypts privateKeys, in the order of accounts, into a
+// backup.
+func sealWalletBackup(accounts []WalletBackupAccount, privateKeys [][]byte, backupPassword string) ([]byte, error) {
+	if backupPassword == "" {
+		return nil, errors.New("backup password is required")
+	}
+
+	secrets := make([]walletBackupSecret, len(accounts))
+	for i, account := range accounts {
+		secrets[i] = walletBackupSecret{Owner: account.Owner, PrivateKey: string(privateKeys[i])}
+	}
+
+	payload, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup accounts: %w", err)
+	}
+	defer clearBytes(payload)
+
+	kdf, err := NewKeysetKDFParams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup KDF params: %w", err)
+	}
+
+	backup := WalletBackup{
+		Format:    WalletBackupFormat,
+		Version:   walletBackupVersion,
+		CreatedAt: time.Now().UTC(),
+		Accounts:  accounts,
+		KDF:       kdf,
+	}
+
+	associatedData, err := backup.associatedData()
+	if err != nil {
+		return nil, err
+	}
+
+	passwordAEAD, err := NewPasswordAEAD(backupPassword, kdf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup AEAD: %w", err)
+	}
+
+	backup.Cipher, err = passwordAEAD.Encrypt(payload, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt backup: %w", err)
+	}
+
+	backup.Checksum, err = backup.checksum()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet backup: %w", err)
+	}
+
+	return data, nil
+}
+
+// openWalletBackup decrypts data and returns its accounts with their private
+// keys in hex, each checked against the public key in the header.
+func openWalletBackup(data []byte, backupPassword string) ([]WalletBackupAccount, [][]byte, error) {
+	if backupPassword == "" {
+		return nil, nil, errors.New("backup password is required")
+	}
+
+	backup, err := ParseWalletBackup(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	associatedData, err := backup.associatedData()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	passwordAEAD, err := NewPasswordAEAD(backupPassword, backup.KDF)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create backup AEAD: %w", err)
+	}
+
+	payload, err := passwordAEAD.Decrypt(backup.Cipher, associatedData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt wallet backup: %w", err)
+	}
+	defer clearBytes(payload)
+
+	var secrets []walletBackupSecret
+	if err := json.Unmarshal(payload, &secrets); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal backup accounts: %w", err)
+	}
+
+	if len(secrets) != len(backup.Accounts) {
+		return nil, nil, errors.New("wallet backup accounts do not match its header")
+	}
+
+	privateKeys := make([][]byte, 0, len(secrets))
+	for i, secret := range secrets {
+		publicKey, err := keys.PublicKeyFromEd25519PrivateHex(secret.PrivateKey)
+		if err != nil || keys.PublicKeyToHex(publicKey) != backup.Accounts[i].Owner || secret.Owner != backup.Accounts[i].Owner {
+			clearBackupKeys(privateKeys)
+			return nil, nil, fmt.Errorf("wallet backup account %s does not match its private key", backup.Accounts[i].Label)
+		}
+		privateKeys = append(privateKeys, []byte(secret.PrivateKey))
+	}
+
+	return backup.Accounts, privateKeys, nil
+}
+
+// associatedData binds the header to the cipher, so that the account list
+// cannot be edited without the password.
+func (b *WalletBackup) associatedData() ([]byte, error) {
+	header, err := json.Marshal(struct {
+		Format   string                `json:"format"`
+		Version  int                   `json:"version"`
+		Accounts []WalletBackupAccount `json:"accounts"`
+		KDF      KeysetKDFParams       `json:"kdf"`
+	}{b.Format, b.Version, b.Accounts, b.KDF})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup header: %w", err)
+	}
+
+	return header, nil
+}
+
+func (b *WalletBackup) checksum() (string, error) {
+	associatedData, err := b.associatedData()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.New()
+	sum.Write(associatedData)
+	sum.Write(b.Cipher)
+
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+func clearBackupKeys(privateKeys [][]byte) {
+	for _, privateKey := range privateKeys {
+		clearBytes(privateKey)
+	}
+}
@@ -8,7 +8,14 @@ import (
 	"path/filepath"
 )
 
-const walletFileAssociatedData = "wallet-manager-file:v1"
+const (
+	walletFileAssociatedData = "wallet-manager-file:v1"
+
+	// walletBackupCount is how many previous versions of a wallet file Write
+	// keeps, as FilePath.bak.1 (the newest) to FilePath.bak.3.
+	walletBackupCount = 3
+	walletBackupExt   = ".bak."
+)
 
 type LocalEncryptedWalletFile struct {
 	KDF    KeysetKDFParams `json:"kdf"`
@@ -24,6 +31,7 @@ type IEncryptionFile interface {
 	Decrypt(localFile LocalEncryptedWalletFile, password string) ([]byte, error)
 	Write(localFile LocalEncryptedWalletFile) error
 	Read() (*LocalEncryptedWalletFile, error)
+	Backups() ([]string, error)
 }
 
 func NewEncryptionFile(filePath string) IEncryptionFile {
@@ -62,6 +70,10 @@ func (e *EncryptionFile) Encrypt(data []byte, password string) (*LocalEncryptedW
 	}, nil
 }
 
+// Write replaces the wallet file atomically: a crash leaves either the old or
+// the new file, never a truncated one. The file it replaces becomes the newest
+// of the rolling backups, which stay encrypted under the password they were
+// written with.
 func (e *EncryptionFile) Write(localFile LocalEncryptedWalletFile) error {
 	if e.FilePath == "" {
 		return fmt.Errorf("wallet file path is required")
@@ -82,13 +94,95 @@ func (e *EncryptionFile) Write(localFile LocalEncryptedWalletFile) error {
 		return fmt.Errorf("failed to marshal encrypted wallet file: %w", err)
 	}
 
-	if err := os.WriteFile(e.FilePath, finalBytes, 0600); err != nil {
+	if err := rotateWalletBackups(e.FilePath); err != nil {
+		return fmt.Errorf("failed to back up encrypted wallet file: %w", err)
+	}
+
+	if err := WriteFileAtomic(e.FilePath, finalBytes, 0600); err != nil {
 		return fmt.Errorf("failed to write encrypted wallet file: %w", err)
 	}
 
 	return nil
 }
 
+// Backups returns the paths of the rolling backups of the wallet file, newest
+// first. Each one is a complete encrypted wallet file that Read can open.
+func (e *EncryptionFile) Backups() ([]string, error) {
+	if e.FilePath == "" {
+		return nil, fmt.Errorf("wallet file path is required")
+	}
+
+	var backups []string
+	for i := 1; i <= walletBackupCount; i++ {
+		backupPath := walletBackupName(e.FilePath, i)
+		if _, err := os.Stat(backupPath); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to stat wallet backup: %w", err)
+		}
+		backups = append(backups, backupPath)
+	}
+
+	return backups, nil
+}
+
+func walletBackupName(filePath string, n int) string {
+	return fmt.Sprintf("%s%s%d", filePath, walletBackupExt, n)
+}
+
+// rotateWalletBackups shifts the backups of filePath by one, dropping the
+// oldest, and copies filePath to the newest slot.
+func rotateWalletBackups(filePath string) error {
+	current, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := walletBackupCount - 1; i >= 1; i-- {
+		err := os.Rename(walletBackupName(filePath, i), walletBackupName(filePath, i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return WriteFileAtomic(walletBackupName(filePath, 1), current, 0600)
+}
+
+// removeWalletBackups deletes the rolling backups of filePath.
+func removeWalletBackups(filePath string) error {
+	for i := 1; i <= walletBackupCount; i++ {
+		err := os.Remove(walletBackupName(filePath, i))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walletBackupOpensWith reports whether password decrypts the encrypted
+// wallet file at backupPath.
+func walletBackupOpensWith(backupPath string, password string) bool {
+	backupFile := &EncryptionFile{FilePath: backupPath}
+
+	localFile, err := backupFile.Read()
+	if err != nil {
+		return false
+	}
+
+	payload, err := backupFile.Decrypt(*localFile, password)
+	if err != nil {
+		return false
+	}
+	clearBytes(payload)
+
+	return true
+}
+
 func (e *EncryptionFile) Read() (*LocalEncryptedWalletFile, error) {
 	if e.FilePath == "" {
 		return nil, fmt.Errorf("wallet file path is required")
@@ -0,0 +1,60 @@
+package wallet_manager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	fileLockExt     = ".lock"
+	fileLockTimeout = 10 * time.Second
+	fileLockRetry   = 50 * time.Millisecond
+)
+
+// ErrFileLocked is returned when another process keeps a file locked with
+// LockFile for longer than the lock timeout.
+var ErrFileLocked = errors.New("file is locked by another process")
+
+// LockFile takes an exclusive advisory lock on path, through a path.lock file
+// next to it, and returns the function that releases it. It waits up to
+// fileLockTimeout for another process to release the lock.
+//
+// The lock only keeps out processes that use it too: every read-modify-write
+// of wallet files and of the keystore index in this package goes through it,
+// and so do the client's outbox entries.
+func LockFile(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path+fileLockExt, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(fileLockTimeout)
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("%w: %s", ErrFileLocked, path)
+		}
+		time.Sleep(fileLockRetry)
+	}
+
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
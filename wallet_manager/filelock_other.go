@@ -0,0 +1,15 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package wallet_manager
+
+import "os"
+
+// Platforms without flock or LockFileEx get no cross-process locking; writes
+// stay atomic.
+func tryLockFile(*os.File) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
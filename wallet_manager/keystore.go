@@ -0,0 +1,602 @@
+package wallet_manager
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+)
+
+const (
+	keystoreVersion   = 1
+	keystoreIndexFile = "keystore.json"
+	keystoreFileExt   = ".wallet"
+
+	keystoreIndexAssociatedData = "wallet-manager-keystore-index:v1"
+	keystoreFileNameSize        = 16
+)
+
+// ErrAccountNotFound is returned when no keystore account has the requested
+// label or public key.
+var ErrAccountNotFound = errors.New("account not found")
+
+var keystoreLabelPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// KeystoreAccount describes an account held by a Keystore. It carries no
+// secret; the private key stays in the account's encrypted wallet file.
+type KeystoreAccount struct {
+	Label    string `json:"label"`
+	Owner    string `json:"owner"`
+	FileName string `json:"file_name"`
+	// DerivationPath is the SLIP-0010 path of accounts derived from a
+	// mnemonic, empty for imported keys.
+	DerivationPath string    `json:"derivation_path,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// keystoreIndex lists the accounts of a keystore directory. It is stored in
+// keystore.json encrypted with the keystore password, in the
+// LocalEncryptedWalletFile format of wallet files, so that the directory
+// does not tell which accounts it holds.
+type keystoreIndex struct {
+	Version  int               `json:"version"`
+	Accounts []KeystoreAccount `json:"accounts"`
+}
+
+// Keystore keeps many labeled Ed25519 accounts in one directory. Every account
+// is a regular wallet file with a random name, encrypted with its own
+// password exactly like the file written by WalletManager.ImportWallet, next
+// to a keystore.json index encrypted with the keystore password.
+//
+// Each account has one IWalletManager for the lifetime of the Keystore, so
+// unlocking an account once makes it usable by every caller that looks it up.
+type Keystore struct {
+	mu sync.Mutex
+
+	dir      string
+	password string
+	managers map[string]*WalletManager // label -> manager
+
+	// indexAEAD is derived from password and indexKDF, the KDF params of
+	// the index last read or written, so that the index is only stretched
+	// again when another process rewrote it with a new salt.
+	indexAEAD *PasswordAEAD
+	indexKDF  KeysetKDFParams
+}
+
+type IKeystore interface {
+	AddAccount(label string, privateKey []byte, password string) (KeystoreAccount, error)
+	AddDerivedAccount(label, mnemonic, passphrase string, accountIndex uint32, password string) (KeystoreAccount, error)
+	RestoreAccounts(labelPrefix, mnemonic, passphrase string, count uint32, password string) ([]KeystoreAccount, error)
+	RemoveAccount(label string, password string) error
+	ExportBackup(labels []string, password, backupPassword string) ([]byte, error)
+	ImportBackup(backup []byte, backupPassword, password string) ([]KeystoreAccount, error)
+	ListAccounts() ([]KeystoreAccount, error)
+	Account(label string) (IWalletManager, error)
+	AccountByPublicKey(publicKey string) (IWalletManager, error)
+}
+
+// NewKeystore opens the keystore kept in dir. password encrypts its index;
+// it may differ from the passwords of the accounts.
+func NewKeystore(dir string, password string) IKeystore {
+	return &Keystore{
+		dir:      dir,
+		password: password,
+		managers: make(map[string]*WalletManager),
+	}
+}
+
+// AddAccount encrypts privateKey with password into a new wallet file and
+// records it under label. The account is left locked.
+func (k *Keystore) AddAccount(label string, privateKey []byte, password string) (KeystoreAccount, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	unlockIndex, err := k.lockIndexLocked()
+	if err != nil {
+		return KeystoreAccount{}, err
+	}
+	defer unlockIndex()
+
+	return k.addAccountLocked(label, privateKey, "", password)
+}
+
+// AddDerivedAccount derives account accountIndex from mnemonic and
+// passphrase along AccountPath and adds it under label like AddAccount.
+func (k *Keystore) AddDerivedAccount(label, mnemonic, passphrase string, accountIndex uint32, password string) (KeystoreAccount, error) {
+	path := AccountPath(accountIndex)
+	_, privateKeyHex, err := DeriveEd25519KeyPairHex(mnemonic, passphrase, path)
+	if err != nil {
+		return KeystoreAccount{}, err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	unlockIndex, err := k.lockIndexLocked()
+	if err != nil {
+		return KeystoreAccount{}, err
+	}
+	defer unlockIndex()
+
+	return k.addAccountLocked(label, []byte(privateKeyHex), path, password)
+}
+
+// RestoreAccounts derives the first count accounts of mnemonic and adds the
+// ones the keystore does not hold yet, labeled labelPrefix-<index>. It
+// returns the accounts it added.
+func (k *Keystore) RestoreAccounts(labelPrefix, mnemonic, passphrase string, count uint32, password string) ([]KeystoreAccount, error) {
+	if count == 0 {
+		return nil, errors.New("account count is required")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	unlockIndex, err := k.lockIndexLocked()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockIndex()
+
+	index, err := k.readIndexLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[string]bool, len(index.Accounts))
+	for _, account := range index.Accounts {
+		stored[account.Owner] = true
+	}
+
+	var restored []KeystoreAccount
+	for i := uint32(0); i < count; i++ {
+		path := AccountPath(i)
+		publicKeyHex, privateKeyHex, err := DeriveEd25519KeyPairHex(mnemonic, passphrase, path)
+		if err != nil {
+			return restored, err
+		}
+		if stored[publicKeyHex] {
+			continue
+		}
+
+		account, err := k.addAccountLocked(fmt.Sprintf("%s-%d", labelPrefix, i), []byte(privateKeyHex), path, password)
+		if err != nil {
+			return restored, err
+		}
+		restored = append(restored, account)
+	}
+
+	return restored, nil
+}
+
+func (k *Keystore) addAccountLocked(label string, privateKey []byte, derivationPath string, password string) (KeystoreAccount, error) {
+	if err := validateKeystoreLabel(label); err != nil {
+		return KeystoreAccount{}, err
+	}
+
+	if len(privateKey) == 0 {
+		return KeystoreAccount{}, fmt.Errorf("private key is required")
+	}
+
+	publicKey, err := keys.PublicKeyFromEd25519PrivateHex(string(privateKey))
+	if err != nil {
+		return KeystoreAccount{}, fmt.Errorf("failed to derive public key from private key: %w", err)
+	}
+	owner := keys.PublicKeyToHex(publicKey)
+
+	index, err := k.readIndexLocked()
+	if err != nil {
+		return KeystoreAccount{}, err
+	}
+
+	for _, account := range index.Accounts {
+		if account.Label == label {
+			return KeystoreAccount{}, fmt.Errorf("account label already exists: %s", label)
+		}
+		if account.Owner == owner {
+			return KeystoreAccount{}, fmt.Errorf("account already stored as %s", account.Label)
+		}
+	}
+
+	name, err := randomBytes(keystoreFileNameSize)
+	if err != nil {
+		return KeystoreAccount{}, fmt.Errorf("failed to generate wallet file name: %w", err)
+	}
+
+	account := KeystoreAccount{
+		Label:          label,
+		Owner:          owner,
+		FileName:       hex.EncodeToString(name) + keystoreFileExt,
+		DerivationPath: derivationPath,
+		CreatedAt:      time.Now(),
+	}
+
+	manager := newWalletManager(filepath.Join(k.dir, account.FileName))
+	if err := manager.ImportWallet(privateKey, password); err != nil {
+		return KeystoreAccount{}, fmt.Errorf("failed to import account %s: %w", label, err)
+	}
+
+	index.Accounts = append(index.Accounts, account)
+	if err := k.writeIndexLocked(index); err != nil {
+		_ = os.Remove(filepath.Join(k.dir, account.FileName))
+		return KeystoreAccount{}, err
+	}
+
+	k.managers[label] = manager
+
+	return account, nil
+}
+
+// RemoveAccount deletes the account stored under label, with the backups of
+// its wallet file. password must open the account's wallet file.
+func (k *Keystore) RemoveAccount(label string, password string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	unlockIndex, err := k.lockIndexLocked()
+	if err != nil {
+		return err
+	}
+	defer unlockIndex()
+
+	index, err := k.readIndexLocked()
+	if err != nil {
+		return err
+	}
+
+	position := -1
+	for i, account := range index.Accounts {
+		if account.Label == label {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return fmt.Errorf("%w: %s", ErrAccountNotFound, label)
+	}
+	account := index.Accounts[position]
+
+	manager := k.managerLocked(account)
+	privateKey, err := manager.openPrivateKey(password)
+	if err != nil {
+		return fmt.Errorf("failed to open account %s: %w", label, err)
+	}
+	clearBytes(privateKey)
+	_ = manager.Lock()
+
+	index.Accounts = append(index.Accounts[:position], index.Accounts[position+1:]...)
+	if err := k.writeIndexLocked(index); err != nil {
+		return err
+	}
+
+	delete(k.managers, label)
+
+	walletPath := filepath.Join(k.dir, account.FileName)
+	if err := os.Remove(walletPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove wallet file: %w", err)
+	}
+
+	if err := removeWalletBackups(walletPath); err != nil {
+		return fmt.Errorf("failed to remove wallet backups: %w", err)
+	}
+	_ = os.Remove(walletPath + fileLockExt)
+
+	return nil
+}
+
+// ExportBackup writes the accounts stored under labels, or every account when
+// labels is empty, to one backup encrypted with backupPassword. password must
+// open each of them; export accounts with different passwords to separate
+// backups.
+func (k *Keystore) ExportBackup(labels []string, password, backupPassword string) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	index, err := k.readIndexLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := index.Accounts
+	if len(labels) > 0 {
+		selected = make([]KeystoreAccount, 0, len(labels))
+		for _, label := range labels {
+			position := slices.IndexFunc(index.Accounts, func(account KeystoreAccount) bool {
+				return account.Label == label
+			})
+			if position < 0 {
+				return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, label)
+			}
+			selected = append(selected, index.Accounts[position])
+		}
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("keystore holds no account")
+	}
+
+	accounts := make([]WalletBackupAccount, 0, len(selected))
+	privateKeys := make([][]byte, 0, len(selected))
+	defer func() { clearBackupKeys(privateKeys) }()
+
+	for _, account := range selected {
+		privateKey, err := k.managerLocked(account).openPrivateKey(password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open account %s: %w", account.Label, err)
+		}
+		privateKeys = append(privateKeys, privateKey)
+
+		accounts = append(accounts, WalletBackupAccount{
+			Label:          account.Label,
+			Owner:          account.Owner,
+			DerivationPath: account.DerivationPath,
+		})
+	}
+
+	return sealWalletBackup(accounts, privateKeys, backupPassword)
+}
+
+// ImportBackup adds the accounts of backup under their labels, each wallet
+// file encrypted with password. Accounts the keystore already holds are
+// skipped; it returns the accounts it added.
+func (k *Keystore) ImportBackup(backup []byte, backupPassword, password string) ([]KeystoreAccount, error) {
+	accounts, privateKeys, err := openWalletBackup(backup, backupPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer clearBackupKeys(privateKeys)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	unlockIndex, err := k.lockIndexLocked()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockIndex()
+
+	index, err := k.readIndexLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[string]bool, len(index.Accounts))
+	for _, account := range index.Accounts {
+		stored[account.Owner] = true
+	}
+
+	var imported []KeystoreAccount
+	for i, account := range accounts {
+		if stored[account.Owner] {
+			continue
+		}
+
+		added, err := k.addAccountLocked(account.Label, privateKeys[i], account.DerivationPath, password)
+		if err != nil {
+			return imported, err
+		}
+		imported = append(imported, added)
+	}
+
+	return imported, nil
+}
+
+// ListAccounts returns the accounts sorted by label.
+func (k *Keystore) ListAccounts() ([]KeystoreAccount, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	index, err := k.readIndexLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := append([]KeystoreAccount(nil), index.Accounts...)
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Label < accounts[j].Label
+	})
+
+	return accounts, nil
+}
+
+// Account returns the wallet manager of the account stored under label.
+func (k *Keystore) Account(label string) (IWalletManager, error) {
+	return k.find(func(account KeystoreAccount) bool {
+		return account.Label == label
+	}, label)
+}
+
+// AccountByPublicKey returns the wallet manager of the account whose public
+// key is publicKey.
+func (k *Keystore) AccountByPublicKey(publicKey string) (IWalletManager, error) {
+	return k.find(func(account KeystoreAccount) bool {
+		return account.Owner == publicKey
+	}, publicKey)
+}
+
+func (k *Keystore) find(match func(KeystoreAccount) bool, name string) (IWalletManager, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	index, err := k.readIndexLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range index.Accounts {
+		if match(account) {
+			return k.managerLocked(account), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, name)
+}
+
+// managerLocked returns the cached manager of account, creating it on first
+// use. The owner is filled in so GetPublicKey works before the first unlock.
+func (k *Keystore) managerLocked(account KeystoreAccount) *WalletManager {
+	if manager, ok := k.managers[account.Label]; ok {
+		return manager
+	}
+
+	manager := newWalletManager(filepath.Join(k.dir, account.FileName))
+	manager.owner = account.Owner
+	k.managers[account.Label] = manager
+
+	return manager
+}
+
+// lockIndexLocked takes the cross-process lock of the keystore index, held
+// by every change to the accounts.
+func (k *Keystore) lockIndexLocked() (func(), error) {
+	if k.dir == "" {
+		return nil, fmt.Errorf("keystore directory is required")
+	}
+
+	return LockFile(filepath.Join(k.dir, keystoreIndexFile))
+}
+
+func (k *Keystore) readIndexLocked() (keystoreIndex, error) {
+	if k.dir == "" {
+		return keystoreIndex{}, fmt.Errorf("keystore directory is required")
+	}
+	if k.password == "" {
+		return keystoreIndex{}, errors.New("keystore password is required")
+	}
+
+	data, err := os.ReadFile(filepath.Join(k.dir, keystoreIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return keystoreIndex{Version: keystoreVersion}, nil
+	}
+	if err != nil {
+		return keystoreIndex{}, fmt.Errorf("failed to read keystore index: %w", err)
+	}
+
+	var localFile LocalEncryptedWalletFile
+	if err := json.Unmarshal(data, &localFile); err != nil {
+		return keystoreIndex{}, fmt.Errorf("failed to unmarshal keystore index: %w", err)
+	}
+	if len(localFile.Cipher) == 0 {
+		return keystoreIndex{}, fmt.Errorf("keystore index is not encrypted")
+	}
+
+	passwordAEAD, err := k.indexAEADLocked(localFile.KDF)
+	if err != nil {
+		return keystoreIndex{}, err
+	}
+
+	plaintext, err := passwordAEAD.Decrypt(localFile.Cipher, []byte(keystoreIndexAssociatedData))
+	if err != nil {
+		return keystoreIndex{}, fmt.Errorf("failed to decrypt keystore index: %w", err)
+	}
+	defer clearBytes(plaintext)
+
+	var index keystoreIndex
+	if err := json.Unmarshal(plaintext, &index); err != nil {
+		return keystoreIndex{}, fmt.Errorf("failed to unmarshal keystore index: %w", err)
+	}
+
+	if index.Version != keystoreVersion {
+		return keystoreIndex{}, fmt.Errorf("unsupported keystore version: %d", index.Version)
+	}
+
+	return index, nil
+}
+
+func (k *Keystore) writeIndexLocked(index keystoreIndex) error {
+	if k.password == "" {
+		return errors.New("keystore password is required")
+	}
+
+	if err := os.MkdirAll(k.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	kdf := k.indexKDF
+	if k.indexAEAD == nil {
+		var err error
+		if kdf, err = NewKeysetKDFParams(); err != nil {
+			return fmt.Errorf("failed to create keystore index KDF params: %w", err)
+		}
+	}
+
+	passwordAEAD, err := k.indexAEADLocked(kdf)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal keystore index: %w", err)
+	}
+	defer clearBytes(plaintext)
+
+	cipherBytes, err := passwordAEAD.Encrypt(plaintext, []byte(keystoreIndexAssociatedData))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt keystore index: %w", err)
+	}
+
+	data, err := json.Marshal(LocalEncryptedWalletFile{KDF: kdf, Cipher: cipherBytes})
+	if err != nil {
+		return fmt.Errorf("failed to marshal keystore index: %w", err)
+	}
+
+	if err := WriteFileAtomic(filepath.Join(k.dir, keystoreIndexFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to write keystore index: %w", err)
+	}
+
+	return nil
+}
+
+// indexAEADLocked returns the AEAD of the index encrypted with kdf, deriving
+// it only when kdf is not the one of the cached AEAD.
+func (k *Keystore) indexAEADLocked(kdf KeysetKDFParams) (*PasswordAEAD, error) {
+	if k.indexAEAD != nil && sameKDFParams(k.indexKDF, kdf) {
+		return k.indexAEAD, nil
+	}
+
+	if err := validateKDFParams(kdf); err != nil {
+		return nil, fmt.Errorf("invalid keystore index KDF params: %w", err)
+	}
+
+	passwordAEAD, err := NewPasswordAEAD(k.password, kdf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keystore index AEAD: %w", err)
+	}
+
+	k.indexAEAD = passwordAEAD
+	k.indexKDF = kdf
+
+	return passwordAEAD, nil
+}
+
+func sameKDFParams(a, b KeysetKDFParams) bool {
+	return a.Alg == b.Alg &&
+		a.Time == b.Time &&
+		a.MemoryKB == b.MemoryKB &&
+		a.Parallel == b.Parallel &&
+		a.KeyLen == b.KeyLen &&
+		bytes.Equal(a.Salt, b.Salt)
+}
+
+func validateKeystoreLabel(label string) error {
+	if label == "" {
+		return errors.New("account label is required")
+	}
+
+	if !keystoreLabelPattern.MatchString(label) {
+		return fmt.Errorf("invalid account label %q: use up to 64 letters, digits, '.', '_' or '-'", label)
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package wallet_manager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const walletKeyTypeEd25519 = "ed25519"
+
+// WalletMigration records one upgrade of a wallet file.
+type WalletMigration struct {
+	FromVersion int `json:"from_version"`
+	ToVersion   int `json:"to_version"`
+	// BackupFile is the name of the copy of the file taken before the
+	// upgrade, next to the wallet file. RotatePassword removes the copy and
+	// clears it.
+	BackupFile string    `json:"backup_file,omitempty"`
+	MigratedAt time.Time `json:"migrated_at"`
+}
+
+// walletMigrations upgrade a decrypted wallet file by one version: the
+// function at key v turns a version v file into a version v+1 file. Adding a
+// wallet version means adding its step here.
+var walletMigrations = map[int]func(*WalletFile) error{
+	1: migrateWalletV1,
+}
+
+// migrateWalletV1 adds the metadata introduced in version 2. Version 1 only
+// held Ed25519 keys.
+func migrateWalletV1(walletFile *WalletFile) error {
+	walletFile.KeyType = walletKeyTypeEd25519
+	return nil
+}
+
+// Migrate upgrades the wallet file to the current format and KDF parameters,
+// keeping a backup of the old file next to it. It reports whether the file
+// was rewritten. Unlock migrates on its own; Migrate does it without
+// unlocking the wallet.
+func (w *WalletManager) Migrate(password string) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if password == "" {
+		return false, errors.New("password is required")
+	}
+
+	unlockFile, err := w.lockWalletFileLocked()
+	if err != nil {
+		return false, err
+	}
+	defer unlockFile()
+
+	localEncryptedWalletFile, walletFile, err := w.readWalletFileLocked(password)
+	if err != nil {
+		return false, err
+	}
+
+	if w.owner != "" && walletFile.Owner != w.owner {
+		return false, fmt.Errorf("wallet owner mismatch")
+	}
+
+	return w.migrateLocked(password, localEncryptedWalletFile, &walletFile)
+}
+
+// readWalletFileLocked reads and decrypts the wallet file with password.
+func (w *WalletManager) readWalletFileLocked(password string) (*LocalEncryptedWalletFile, WalletFile, error) {
+	if password == "" {
+		return nil, WalletFile{}, errors.New("password is required")
+	}
+
+	if w.filePath == "" {
+		return nil, WalletFile{}, fmt.Errorf("wallet file path is required")
+	}
+
+	encryptionFile := NewEncryptionFile(w.filePath)
+
+	localEncryptedWalletFile, err := encryptionFile.Read()
+	if err != nil {
+		return nil, WalletFile{}, fmt.Errorf("failed to read wallet file: %w", err)
+	}
+
+	if err := validateKDFParams(localEncryptedWalletFile.KDF); err != nil {
+		return nil, WalletFile{}, fmt.Errorf("invalid wallet file: %w", err)
+	}
+
+	walletPayload, err := encryptionFile.Decrypt(*localEncryptedWalletFile, password)
+	if err != nil {
+		return nil, WalletFile{}, fmt.Errorf("failed to decrypt wallet file: %w", err)
+	}
+	defer clearBytes(walletPayload)
+
+	var walletFile WalletFile
+	if err := json.Unmarshal(walletPayload, &walletFile); err != nil {
+		return nil, WalletFile{}, fmt.Errorf("failed to unmarshal wallet file: %w", err)
+	}
+
+	if err := checkWalletVersion(walletFile.Version); err != nil {
+		return nil, WalletFile{}, err
+	}
+
+	if err := validateKDFParams(walletFile.WrappedKeyset.KDF); err != nil {
+		return nil, WalletFile{}, fmt.Errorf("invalid wallet keyset: %w", err)
+	}
+
+	if walletFile.Owner == "" {
+		return nil, WalletFile{}, fmt.Errorf("wallet owner is required")
+	}
+
+	return localEncryptedWalletFile, walletFile, nil
+}
+
+// checkWalletVersion accepts the current version and every older one a
+// migration exists for.
+func checkWalletVersion(version int) error {
+	if version < 1 || version > walletVersion {
+		return fmt.Errorf("unsupported wallet version: %d", version)
+	}
+
+	return nil
+}
+
+func walletNeedsMigration(localFile LocalEncryptedWalletFile, walletFile WalletFile) bool {
+	return walletFile.Version < walletVersion ||
+		kdfParamsOutdated(localFile.KDF) ||
+		kdfParamsOutdated(walletFile.WrappedKeyset.KDF)
+}
+
+// migrateLocked runs the migrations walletFile needs, re-wraps the Tink
+// keyset and re-encrypts the file under the current KDF parameters, and
+// replaces the wallet file atomically once a backup of the old one is on
+// disk. Nothing is written when the file is current.
+func (w *WalletManager) migrateLocked(password string, localFile *LocalEncryptedWalletFile, walletFile *WalletFile) (bool, error) {
+	if !walletNeedsMigration(*localFile, *walletFile) {
+		return false, nil
+	}
+
+	original, err := os.ReadFile(w.filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read wallet file: %w", err)
+	}
+
+	fromVersion := walletFile.Version
+	for walletFile.Version < walletVersion {
+		migrate, ok := walletMigrations[walletFile.Version]
+		if !ok {
+			return false, fmt.Errorf("no migration from wallet version %d", walletFile.Version)
+		}
+
+		if err := migrate(walletFile); err != nil {
+			return false, fmt.Errorf("failed to migrate wallet version %d: %w", walletFile.Version, err)
+		}
+		walletFile.Version++
+	}
+
+	if kdfParamsOutdated(walletFile.WrappedKeyset.KDF) {
+		kh, err := UnwrapTinkKeyset(walletFile.WrappedKeyset, password)
+		if err != nil {
+			return false, fmt.Errorf("failed to unwrap keyset: %w", err)
+		}
+
+		wrappedKeyset, err := WrapTinkKeyset(kh, password)
+		if err != nil {
+			return false, fmt.Errorf("failed to wrap keyset: %w", err)
+		}
+		walletFile.WrappedKeyset = wrappedKeyset
+	}
+
+	now := time.Now()
+
+	backupPath := walletBackupPath(w.filePath, fromVersion, now)
+	if err := WriteFileAtomic(backupPath, original, 0600); err != nil {
+		return false, fmt.Errorf("failed to back up wallet file: %w", err)
+	}
+
+	walletFile.Migrations = append(walletFile.Migrations, WalletMigration{
+		FromVersion: fromVersion,
+		ToVersion:   walletVersion,
+		BackupFile:  filepath.Base(backupPath),
+		MigratedAt:  now,
+	})
+	walletFile.UpdatedAt = now
+
+	walletPayload, err := json.Marshal(walletFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal wallet file: %w", err)
+	}
+	defer clearBytes(walletPayload)
+
+	migratedFile, err := NewEncryptionFile(w.filePath).Encrypt(walletPayload, password)
+	if err != nil {
+		return false, fmt.Errorf("failed to encrypt wallet file: %w", err)
+	}
+
+	finalBytes, err := json.Marshal(migratedFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal encrypted wallet file: %w", err)
+	}
+
+	if err := WriteFileAtomic(w.filePath, finalBytes, 0600); err != nil {
+		return false, fmt.Errorf("failed to write migrated wallet file: %w", err)
+	}
+
+	return true, nil
+}
+
+// walletBackupPath names the backup of a version fromVersion wallet file,
+// for example alice.wallet.v1-20260102T150405Z.bak.
+func walletBackupPath(filePath string, fromVersion int, now time.Time) string {
+	return fmt.Sprintf("%s.v%d-%s.bak", filePath, fromVersion, now.UTC().Format("20060102T150405Z"))
+}
+
+// migrationBackupPath returns the path of the migration backup named
+// backupFile, which sits next to the wallet file at filePath.
+func migrationBackupPath(filePath string, backupFile string) (string, error) {
+	// The names come from the decrypted wallet file; never follow one out of
+	// the wallet directory.
+	if backupFile != filepath.Base(backupFile) {
+		return "", fmt.Errorf("invalid wallet backup name: %s", backupFile)
+	}
+
+	return filepath.Join(filepath.Dir(filePath), backupFile), nil
+}
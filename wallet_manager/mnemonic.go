@@ -0,0 +1,200 @@
+package wallet_manager
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+)
+
+const (
+	// MnemonicEntropyBits is the entropy of the mnemonics NewMnemonic
+	// generates by default, giving 24 words.
+	MnemonicEntropyBits = 256
+
+	// HDCoinType is the coin type of 2Finance account paths.
+	//
+	// It is NOT a registered SLIP-0044 coin type: 2Finance has no entry in
+	// that registry, and coin type 1, the usual stand-in, is shared by the
+	// testnets of every coin. 2222 was picked as a free, easy to recognize
+	// number. Nothing reserves it, so another coin may register or use it,
+	// and a wallet that derives several coins from one phrase could then
+	// derive the same keys for both. Registering 2Finance in SLIP-0044
+	// should claim this number rather than a new one.
+	//
+	// It is part of every account path: a phrase only restores its accounts
+	// under the same coin type, so changing it changes every derived address
+	// and it must never change.
+	HDCoinType uint32 = 2222
+
+	slip10Ed25519Curve = "ed25519 seed"
+	hardenedOffset     = uint32(0x80000000)
+)
+
+// NewMnemonic returns a new English BIP-39 mnemonic. bits is the entropy
+// size: a multiple of 32 from 128 (12 words) to 256 (24 words).
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", fmt.Errorf("failed to create mnemonic entropy: %w", err)
+	}
+	defer clearBytes(entropy)
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("failed to create mnemonic: %w", err)
+	}
+
+	return mnemonic, nil
+}
+
+// ValidateMnemonic checks the words and the checksum of mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	if strings.TrimSpace(mnemonic) == "" {
+		return errors.New("mnemonic is required")
+	}
+
+	if _, err := bip39.EntropyFromMnemonic(normalizeMnemonic(mnemonic)); err != nil {
+		return fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	return nil
+}
+
+// AccountPath returns the SLIP-0010 path of account index,
+// m/44'/HDCoinType'/index'/0'/0'. Every level is hardened because SLIP-0010
+// only defines hardened derivation for Ed25519.
+func AccountPath(index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0'/0'", HDCoinType, index)
+}
+
+// DeriveEd25519KeyPairHex derives the key pair at path from mnemonic and the
+// optional BIP-39 passphrase. It returns the public and private keys in the
+// same hex form as GenerateEd25519KeyPairHex.
+func DeriveEd25519KeyPairHex(mnemonic, passphrase, path string) (string, string, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return "", "", err
+	}
+
+	indexes, err := parseHardenedPath(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	seed := bip39.NewSeed(normalizeMnemonic(mnemonic), passphrase)
+	defer clearBytes(seed)
+
+	return deriveEd25519KeyPairHex(seed, indexes)
+}
+
+// DeriveEd25519KeyPairHexFromSeed derives the key pair at path from a
+// BIP-39 seed, or any SLIP-0010 seed of 16 to 64 bytes, like
+// DeriveEd25519KeyPairHex.
+func DeriveEd25519KeyPairHexFromSeed(seed []byte, path string) (string, string, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return "", "", fmt.Errorf("seed must be 16 to 64 bytes: %d", len(seed))
+	}
+
+	indexes, err := parseHardenedPath(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	return deriveEd25519KeyPairHex(seed, indexes)
+}
+
+// deriveEd25519KeyPairHex runs the SLIP-0010 Ed25519 derivation of seed
+// along the hardened child indexes.
+func deriveEd25519KeyPairHex(seed []byte, indexes []uint32) (string, string, error) {
+	key, chainCode := slip10Master(seed)
+	for _, index := range indexes {
+		childKey, childChainCode := slip10Child(key, chainCode, index)
+		clearBytes(key)
+		clearBytes(chainCode)
+		key, chainCode = childKey, childChainCode
+	}
+	defer clearBytes(key)
+	defer clearBytes(chainCode)
+
+	privateKey := ed25519.NewKeyFromSeed(key)
+	defer clearBytes(privateKey)
+
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	return keys.PublicKeyToHex(publicKey), keys.PrivateKeyToHex(privateKey), nil
+}
+
+// ImportMnemonic derives account accountIndex from mnemonic and passphrase
+// and imports it like ImportWallet, encrypted with password.
+func (w *WalletManager) ImportMnemonic(mnemonic, passphrase string, accountIndex uint32, password string) error {
+	_, privateKeyHex, err := DeriveEd25519KeyPairHex(mnemonic, passphrase, AccountPath(accountIndex))
+	if err != nil {
+		return err
+	}
+
+	return w.ImportWallet([]byte(privateKeyHex), password)
+}
+
+// slip10Master returns the master key and chain code of seed.
+func slip10Master(seed []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, []byte(slip10Ed25519Curve))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	return sum[:32], sum[32:]
+}
+
+// slip10Child returns the hardened child index of the parent key.
+func slip10Child(key, chainCode []byte, index uint32) ([]byte, []byte) {
+	data := make([]byte, 0, 1+len(key)+4)
+	data = append(data, 0)
+	data = append(data, key...)
+	data = binary.BigEndian.AppendUint32(data, index)
+	defer clearBytes(data)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	return sum[:32], sum[32:]
+}
+
+// parseHardenedPath parses a path such as m/44'/1'/0'/0'/0' into child
+// indexes with the hardened bit set. Unhardened levels are rejected.
+func parseHardenedPath(path string) ([]uint32, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+	if len(segments) == 0 || segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with m", path)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		number, hardened := strings.CutSuffix(segment, "'")
+		if !hardened {
+			number, hardened = strings.CutSuffix(segment, "h")
+		}
+		if !hardened {
+			return nil, fmt.Errorf("invalid derivation path %q: ed25519 only supports hardened levels", path)
+		}
+
+		index, err := strconv.ParseUint(number, 10, 32)
+		if err != nil || uint32(index) >= hardenedOffset {
+			return nil, fmt.Errorf("invalid derivation path %q: bad level %q", path, segment)
+		}
+
+		indexes = append(indexes, uint32(index)+hardenedOffset)
+	}
+
+	return indexes, nil
+}
+
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
@@ -0,0 +1,390 @@
+package wallet_manager
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/utils"
+)
+
+const (
+	// MultisigFormat identifies multi-signature envelopes in their format
+	// field.
+	MultisigFormat = "2finance-multisig"
+
+	multisigVersion = 1
+
+	// multisigDigestDomain separates approval signatures from transaction
+	// signatures, so that an approval can never be replayed as one.
+	multisigDigestDomain = "2finance-multisig:v1\n"
+)
+
+// ErrMultisigThreshold is returned when a multi-signature transaction is
+// signed for submission with fewer valid approvals than its threshold.
+var ErrMultisigThreshold = errors.New("multisig threshold not met")
+
+// ApprovalSigner is a Signer that also approves multi-signature
+// transactions. It signs the Digest it computes itself, never bytes handed to
+// it, so an approval key cannot be made to sign anything else.
+type ApprovalSigner interface {
+	Signer
+
+	// SignApproval returns the Ed25519 signature of m's Digest. The signer
+	// must be one of m's Signers.
+	SignApproval(m *MultisigTransaction) ([]byte, error)
+}
+
+// MultisigSigner is a Signer that signs a MultisigTransaction for its From
+// account as a whole, so that the side holding the key checks the
+// approvals. RemoteSigner is one.
+type MultisigSigner interface {
+	Signer
+
+	// SignMultisigTransaction checks the threshold of m and signs its
+	// transaction.
+	SignMultisigTransaction(m *MultisigTransaction) (*transaction.Transaction, error)
+}
+
+// MultisigTransaction carries an unsigned transaction between the parties of
+// a multi-signature flow. One party builds it and serializes it with
+// json.Marshal; each approver adds an Ed25519 signature of Digest, possibly
+// offline on another machine; once Threshold of the listed Signers have
+// approved, the account in Transaction.From signs the transaction itself and
+// submits it.
+//
+// This is not on-chain multisig: the network checks the signature of
+// Transaction.From only. The approvals bind only when the From key refuses
+// to sign without them, that is when it is held by a signing daemon serving
+// RemoteSignerHandler with WithMultisigOnly. A From key held in this
+// process or on a token can sign anything, approved or not.
+type MultisigTransaction struct {
+	Format      string                  `json:"format"`
+	Version     int                     `json:"version"`
+	Transaction transaction.Transaction `json:"transaction"`
+	Threshold   int                     `json:"threshold"`
+	Signers     []string                `json:"signers"`
+	Approvals   []MultisigApproval      `json:"approvals"`
+}
+
+// MultisigApproval is the signature of one approver over Digest.
+type MultisigApproval struct {
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
+}
+
+// NewMultisigTransaction builds an unsigned transaction that needs threshold
+// approvals from the public keys in signers.
+func NewMultisigTransaction(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string, threshold int, signers []string) (*MultisigTransaction, error) {
+	tx, err := NewUnsignedTransaction(chainId, from, to, method, data, version, uuid7)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MultisigTransaction{
+		Format:      MultisigFormat,
+		Version:     multisigVersion,
+		Transaction: *tx,
+		Threshold:   threshold,
+		Signers:     slices.Clone(signers),
+		Approvals:   []MultisigApproval{},
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ParseMultisigTransaction decodes a serialized MultisigTransaction. It does
+// not check the approvals; Approvers does.
+func ParseMultisigTransaction(data []byte) (*MultisigTransaction, error) {
+	var m MultisigTransaction
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal multisig transaction: %w", err)
+	}
+
+	if m.Format != MultisigFormat {
+		return nil, fmt.Errorf("not a multisig transaction: format %q", m.Format)
+	}
+
+	if m.Version != multisigVersion {
+		return nil, fmt.Errorf("unsupported multisig transaction version: %d", m.Version)
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (m *MultisigTransaction) validate() error {
+	if m.Transaction.Hash != "" || m.Transaction.Signature != "" {
+		return errors.New("multisig transaction must be unsigned")
+	}
+
+	if len(m.Signers) == 0 {
+		return errors.New("multisig signers are required")
+	}
+
+	seen := make(map[string]bool, len(m.Signers))
+	for _, signer := range m.Signers {
+		if _, err := decodeEd25519PublicKey(signer); err != nil {
+			return fmt.Errorf("invalid multisig signer %q: %w", signer, err)
+		}
+		if seen[signer] {
+			return fmt.Errorf("multisig signer %s listed twice", signer)
+		}
+		seen[signer] = true
+	}
+
+	if m.Threshold < 1 || m.Threshold > len(m.Signers) {
+		return fmt.Errorf("multisig threshold must be between 1 and %d: %d", len(m.Signers), m.Threshold)
+	}
+
+	return nil
+}
+
+// Digest returns what approvers sign: a hash of the transaction, the
+// threshold and the signers, so an approval cannot be moved to another
+// transaction or policy.
+func (m *MultisigTransaction) Digest() ([]byte, error) {
+	data, err := canonicalJSON(m.Transaction.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction data: %w", err)
+	}
+
+	tx := m.Transaction
+	tx.Data = data
+
+	payload, err := json.Marshal(struct {
+		Transaction transaction.Transaction `json:"transaction"`
+		Threshold   int                     `json:"threshold"`
+		Signers     []string                `json:"signers"`
+	}{tx, m.Threshold, m.Signers})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal multisig digest: %w", err)
+	}
+
+	sum := sha256.Sum256(append([]byte(multisigDigestDomain), payload...))
+
+	return sum[:], nil
+}
+
+// Approve adds the approval of signer, which must be one of Signers. An
+// earlier approval by the same key is replaced.
+func (m *MultisigTransaction) Approve(signer ApprovalSigner) error {
+	if signer == nil {
+		return errors.New("signer is required")
+	}
+
+	signature, err := signer.SignApproval(m)
+	if err != nil {
+		return fmt.Errorf("failed to sign approval: %w", err)
+	}
+
+	return m.AddApproval(signer.GetPublicKey(), signature)
+}
+
+// AddApproval adds a signature of Digest made elsewhere by publicKey, which
+// must be one of Signers. The signature is checked before it is added.
+func (m *MultisigTransaction) AddApproval(publicKey string, signature []byte) error {
+	if !slices.Contains(m.Signers, publicKey) {
+		return fmt.Errorf("%s is not a signer of this transaction", publicKey)
+	}
+
+	digest, err := m.Digest()
+	if err != nil {
+		return err
+	}
+
+	if err := verifyApproval(publicKey, digest, signature); err != nil {
+		return err
+	}
+
+	approval := MultisigApproval{Signer: publicKey, Signature: hex.EncodeToString(signature)}
+	for i := range m.Approvals {
+		if m.Approvals[i].Signer == publicKey {
+			m.Approvals[i] = approval
+			return nil
+		}
+	}
+	m.Approvals = append(m.Approvals, approval)
+
+	return nil
+}
+
+// Approvers returns the signers whose approval verifies against the current
+// transaction, in Signers order. Approvals from unknown keys or that do not
+// verify are ignored.
+func (m *MultisigTransaction) Approvers() ([]string, error) {
+	digest, err := m.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	approved := make(map[string]bool, len(m.Approvals))
+	for _, approval := range m.Approvals {
+		signature, err := hex.DecodeString(approval.Signature)
+		if err != nil {
+			continue
+		}
+		if slices.Contains(m.Signers, approval.Signer) && verifyApproval(approval.Signer, digest, signature) == nil {
+			approved[approval.Signer] = true
+		}
+	}
+
+	approvers := make([]string, 0, len(approved))
+	for _, signer := range m.Signers {
+		if approved[signer] {
+			approvers = append(approvers, signer)
+		}
+	}
+
+	return approvers, nil
+}
+
+// CheckThreshold fails with ErrMultisigThreshold until Threshold signers
+// have approved.
+func (m *MultisigTransaction) CheckThreshold() error {
+	approvers, err := m.Approvers()
+	if err != nil {
+		return err
+	}
+
+	if len(approvers) < m.Threshold {
+		return fmt.Errorf("%w: %d of %d approvals", ErrMultisigThreshold, len(approvers), m.Threshold)
+	}
+
+	return nil
+}
+
+// TransactionData returns the transaction data in the form Signer takes it.
+func (m *MultisigTransaction) TransactionData() (utils.JSONB, error) {
+	var data utils.JSONB
+	if len(m.Transaction.Data) > 0 {
+		if err := json.Unmarshal(m.Transaction.Data, &data); err != nil {
+			return nil, fmt.Errorf("invalid transaction data: %w", err)
+		}
+	}
+
+	return data, nil
+}
+
+// SignTransaction checks the threshold and has signer, the account in
+// Transaction.From, sign the transaction for submission. A MultisigSigner is
+// given the whole of m, to check the threshold again on its side.
+func (m *MultisigTransaction) SignTransaction(signer Signer) (*transaction.Transaction, error) {
+	if err := m.CheckThreshold(); err != nil {
+		return nil, err
+	}
+
+	if multisigSigner, ok := signer.(MultisigSigner); ok {
+		return multisigSigner.SignMultisigTransaction(m)
+	}
+
+	data, err := m.TransactionData()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := m.Transaction
+
+	return signer.SignTransaction(tx.ChainID, tx.From, tx.To, tx.Method, data, tx.Version, tx.UUID7)
+}
+
+// SignApproval signs the Digest of m with the unlocked key. It counts
+// against the unlock policy like a transaction signature.
+func (w *WalletManager) SignApproval(m *MultisigTransaction) ([]byte, error) {
+	notify := noNotice
+	defer func() { notify() }()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.isUnlockedLocked() {
+		return nil, &WalletLockedError{Owner: w.owner, Method: "SignApproval"}
+	}
+
+	digest, err := m.approvalDigest(w.owner)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := hex.DecodeString(string(w.privateKey))
+	if err != nil || len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid private key")
+	}
+	defer clearBytes(privateKey)
+
+	signature := ed25519.Sign(ed25519.PrivateKey(privateKey), digest)
+	notify = w.recordSignatureLocked()
+
+	return signature, nil
+}
+
+// SignApproval signs the Digest of m on the token.
+func (s *PKCS11Signer) SignApproval(m *MultisigTransaction) ([]byte, error) {
+	digest, err := m.approvalDigest(s.GetPublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.token.Sign(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign approval on token: %w", err)
+	}
+
+	if !ed25519.Verify(s.publicKey, digest, signature) {
+		return nil, errors.New("token signature does not verify")
+	}
+
+	return signature, nil
+}
+
+// approvalDigest returns the Digest of m for publicKey to approve, which must
+// be one of Signers.
+func (m *MultisigTransaction) approvalDigest(publicKey string) ([]byte, error) {
+	if m == nil {
+		return nil, errors.New("multisig transaction is required")
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(m.Signers, publicKey) {
+		return nil, fmt.Errorf("%s is not a signer of this transaction", publicKey)
+	}
+
+	return m.Digest()
+}
+
+func verifyApproval(publicKey string, digest, signature []byte) error {
+	key, err := decodeEd25519PublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(key, digest, signature) {
+		return fmt.Errorf("approval of %s does not verify", publicKey)
+	}
+
+	return nil
+}
+
+func decodeEd25519PublicKey(publicKey string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("not a hex Ed25519 public key")
+	}
+
+	return ed25519.PublicKey(key), nil
+}
@@ -12,10 +12,17 @@ import (
 const (
 	keysetSaltSize = 16
 
+	// Argon2id parameters for new keys. Wallet files written before version
+	// 2 used 64 MiB and one thread; Unlock re-derives them with these.
 	argonTime    uint32 = 3
-	argonMemory  uint32 = 64 * 1024
-	argonThreads uint8  = 1
+	argonMemory  uint32 = 128 * 1024
+	argonThreads uint8  = 4
 	argonKeyLen  uint32 = 32
+
+	// Upper bounds on the Argon2id parameters read from files and backups,
+	// so that a crafted file cannot make opening it exhaust memory or time.
+	maxArgonTime   uint32 = 16
+	maxArgonMemory uint32 = 1024 * 1024
 )
 
 type KeysetKDFParams struct {
@@ -47,6 +54,41 @@ func NewKeysetKDFParams() (KeysetKDFParams, error) {
 	}, nil
 }
 
+// kdfParamsOutdated reports whether params are weaker than the ones
+// NewKeysetKDFParams creates.
+func kdfParamsOutdated(params KeysetKDFParams) bool {
+	return params.Alg != "argon2id" ||
+		params.Time < argonTime ||
+		params.MemoryKB < argonMemory ||
+		params.Parallel < argonThreads ||
+		params.KeyLen < argonKeyLen
+}
+
+// validateKDFParams checks KDF params read from a file or a backup before
+// they are used to derive a key.
+func validateKDFParams(params KeysetKDFParams) error {
+	if params.Alg != "argon2id" {
+		return fmt.Errorf("unsupported KDF algorithm: %s", params.Alg)
+	}
+	if params.Time < 1 || params.Time > maxArgonTime {
+		return fmt.Errorf("KDF time must be between 1 and %d: %d", maxArgonTime, params.Time)
+	}
+	if params.Parallel < 1 {
+		return fmt.Errorf("KDF parallelism must be at least 1")
+	}
+	if params.MemoryKB < 8*uint32(params.Parallel) || params.MemoryKB > maxArgonMemory {
+		return fmt.Errorf("KDF memory must be between 8 KiB per thread and %d KiB: %d", maxArgonMemory, params.MemoryKB)
+	}
+	if params.KeyLen != argonKeyLen {
+		return fmt.Errorf("KDF key length must be %d: %d", argonKeyLen, params.KeyLen)
+	}
+	if len(params.Salt) == 0 {
+		return fmt.Errorf("salt is required")
+	}
+
+	return nil
+}
+
 func NewPasswordAEAD(password string, params KeysetKDFParams) (*PasswordAEAD, error) {
 	if password == "" {
 		return nil, errors.New("password is required")
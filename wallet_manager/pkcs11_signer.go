@@ -0,0 +1,131 @@
+package wallet_manager
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/utils"
+)
+
+// PKCS11Token is the boundary to a PKCS#11 token, HSM or any device that
+// holds an Ed25519 key and signs with it (CKM_EDDSA) without ever exporting
+// it. An implementation typically wraps one logged-in session of a PKCS#11
+// library and one key object; this package does not link a PKCS#11 library
+// itself.
+type PKCS11Token interface {
+	// PublicKey returns the public half of the token key.
+	PublicKey() (ed25519.PublicKey, error)
+
+	// Sign signs message with the token key.
+	Sign(message []byte) ([]byte, error)
+}
+
+// TransactionDigest returns the hash of tx that the network verifies its
+// signature against. It must hash tx exactly like the network does.
+type TransactionDigest func(tx *transaction.Transaction) ([]byte, error)
+
+// digestKey is the throwaway key NetworkTransactionDigest signs with.
+var digestKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+// NetworkTransactionDigest is the TransactionDigest of the network: the hash
+// transaction.SignTransactionHexKey sets. That hash covers the unsigned
+// transaction only, so it is read off a copy signed with a throwaway key.
+func NetworkTransactionDigest(tx *transaction.Transaction) ([]byte, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+
+	unsigned := *tx
+	unsigned.Hash, unsigned.Signature = "", ""
+
+	signed, err := transaction.SignTransactionHexKey(keys.PrivateKeyToHex(digestKey), &unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := hex.DecodeString(signed.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %w", err)
+	}
+
+	return digest, nil
+}
+
+// PKCS11Signer is a Signer that asks a PKCS11Token to sign the transaction
+// digest. Hash and Signature are set to the hex encoded digest and signature.
+type PKCS11Signer struct {
+	token     PKCS11Token
+	digest    TransactionDigest
+	publicKey ed25519.PublicKey
+}
+
+// NewPKCS11Signer returns a Signer for the key held by token. A nil digest
+// uses NetworkTransactionDigest, so that the token signs what
+// SignTransactionHexKey would.
+func NewPKCS11Signer(token PKCS11Token, digest TransactionDigest) (*PKCS11Signer, error) {
+	if token == nil {
+		return nil, errors.New("token is required")
+	}
+
+	if digest == nil {
+		digest = NetworkTransactionDigest
+	}
+
+	publicKey, err := token.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token public key: %w", err)
+	}
+
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid token public key length: %d", len(publicKey))
+	}
+
+	return &PKCS11Signer{
+		token:     token,
+		digest:    digest,
+		publicKey: publicKey,
+	}, nil
+}
+
+func (s *PKCS11Signer) GetPublicKey() string {
+	return keys.PublicKeyToHex(s.publicKey)
+}
+
+// IsUnlocked always reports true: the token session enforces its own login.
+func (s *PKCS11Signer) IsUnlocked() bool {
+	return true
+}
+
+func (s *PKCS11Signer) SignTransaction(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error) {
+	if err := checkSigner(s, from); err != nil {
+		return nil, err
+	}
+
+	tx, err := NewUnsignedTransaction(chainId, from, to, method, data, version, uuid7)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := s.digest(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash transaction: %w", err)
+	}
+
+	signature, err := s.token.Sign(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction on token: %w", err)
+	}
+
+	if !ed25519.Verify(s.publicKey, digest, signature) {
+		return nil, errors.New("token signature does not verify")
+	}
+
+	tx.Hash = hex.EncodeToString(digest)
+	tx.Signature = hex.EncodeToString(signature)
+
+	return tx, nil
+}
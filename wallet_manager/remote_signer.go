@@ -0,0 +1,436 @@
+package wallet_manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/utils"
+)
+
+const (
+	remoteSignerTimeout = 30 * time.Second
+
+	remoteSignerPublicKeyPath    = "/v1/public-key"
+	remoteSignerSignPath         = "/v1/sign"
+	remoteSignerSignMultisigPath = "/v1/sign-multisig"
+	remoteSignerSignApprovalPath = "/v1/sign-approval"
+
+	// remoteSignerMaxBody bounds the responses read from a signing daemon.
+	remoteSignerMaxBody = 1 << 20
+)
+
+// remotePublicKeyResponse is the answer to GET /v1/public-key.
+type remotePublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+// remoteSignRequest is the body of POST /v1/sign. The daemon answers with a
+// remoteSignResponse carrying the same transaction with Hash and Signature
+// set.
+type remoteSignRequest struct {
+	Transaction transaction.Transaction `json:"transaction"`
+}
+
+type remoteSignResponse struct {
+	Transaction transaction.Transaction `json:"transaction"`
+	Error       string                  `json:"error,omitempty"`
+}
+
+// remoteMultisigRequest is the body of POST /v1/sign-multisig, answered with
+// a remoteSignResponse, and of POST /v1/sign-approval, answered with a
+// remoteSignApprovalResponse. The latter is served when the daemon's signer
+// is an ApprovalSigner.
+type remoteMultisigRequest struct {
+	Multisig *MultisigTransaction `json:"multisig"`
+}
+
+// remoteSignApprovalResponse carries the approval signature, base64
+// encoded.
+type remoteSignApprovalResponse struct {
+	Signature []byte `json:"signature"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RemoteSigner is a Signer backed by a signing daemon reached over a Unix
+// socket or HTTP, so the private key never enters this process. The daemon
+// speaks a small JSON protocol, served by RemoteSignerHandler:
+//
+//	GET  /v1/public-key    -> {"public_key": "<hex>"}
+//	POST /v1/sign          {"transaction": {...}} -> {"transaction": {...}}
+//	POST /v1/sign-multisig {"multisig": {...}} -> {"transaction": {...}}
+//	POST /v1/sign-approval {"multisig": {...}} -> {"signature": "<base64>"}
+//
+// Errors are answered with a non-2xx status and {"error": "<message>"}.
+type RemoteSigner struct {
+	baseURL    string
+	httpClient *http.Client
+	publicKey  string
+}
+
+// NewRemoteSigner connects to the signing daemon at address and fetches its
+// public key. address is either unix:///path/to/socket or an http(s) URL.
+func NewRemoteSigner(address string) (*RemoteSigner, error) {
+	if address == "" {
+		return nil, errors.New("signer address is required")
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer address: %w", err)
+	}
+
+	s := &RemoteSigner{
+		httpClient: &http.Client{Timeout: remoteSignerTimeout},
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socketPath := u.Path
+		if socketPath == "" {
+			return nil, fmt.Errorf("invalid signer address %q: socket path not set", address)
+		}
+		s.baseURL = "http://signer"
+		s.httpClient.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid signer address %q: host not set", address)
+		}
+		s.baseURL = strings.TrimSuffix(address, "/")
+	default:
+		return nil, fmt.Errorf("invalid signer address %q: unsupported scheme %q", address, u.Scheme)
+	}
+
+	var resp remotePublicKeyResponse
+	if err := s.do(http.MethodGet, remoteSignerPublicKeyPath, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch signer public key: %w", err)
+	}
+
+	if err := keys.ValidateEDDSAPublicKeyHex(resp.PublicKey); err != nil {
+		return nil, fmt.Errorf("invalid signer public key: %w", err)
+	}
+	s.publicKey = resp.PublicKey
+
+	return s, nil
+}
+
+func (s *RemoteSigner) GetPublicKey() string {
+	return s.publicKey
+}
+
+// IsUnlocked always reports true: the daemon applies its own unlock policy
+// and refuses to sign when it is locked.
+func (s *RemoteSigner) IsUnlocked() bool {
+	return true
+}
+
+func (s *RemoteSigner) SignTransaction(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error) {
+	if err := checkSigner(s, from); err != nil {
+		return nil, err
+	}
+
+	tx, err := NewUnsignedTransaction(chainId, from, to, method, data, version, uuid7)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp remoteSignResponse
+	if err := s.do(http.MethodPost, remoteSignerSignPath, remoteSignRequest{Transaction: *tx}, &resp); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction remotely: %w", err)
+	}
+
+	signed := resp.Transaction
+	if err := checkSignedTransaction(tx, &signed); err != nil {
+		return nil, fmt.Errorf("signer returned an invalid transaction: %w", err)
+	}
+
+	return &signed, nil
+}
+
+// SignMultisigTransaction has the daemon check the approvals of m and sign
+// its transaction.
+func (s *RemoteSigner) SignMultisigTransaction(m *MultisigTransaction) (*transaction.Transaction, error) {
+	if m == nil {
+		return nil, errors.New("multisig transaction is required")
+	}
+
+	if err := checkSigner(s, m.Transaction.From); err != nil {
+		return nil, err
+	}
+
+	var resp remoteSignResponse
+	if err := s.do(http.MethodPost, remoteSignerSignMultisigPath, remoteMultisigRequest{Multisig: m}, &resp); err != nil {
+		return nil, fmt.Errorf("failed to sign multisig transaction remotely: %w", err)
+	}
+
+	signed := resp.Transaction
+	if err := checkSignedTransaction(&m.Transaction, &signed); err != nil {
+		return nil, fmt.Errorf("signer returned an invalid transaction: %w", err)
+	}
+
+	return &signed, nil
+}
+
+// SignApproval has the daemon approve m. The daemon must serve an
+// ApprovalSigner.
+func (s *RemoteSigner) SignApproval(m *MultisigTransaction) ([]byte, error) {
+	digest, err := m.approvalDigest(s.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp remoteSignApprovalResponse
+	if err := s.do(http.MethodPost, remoteSignerSignApprovalPath, remoteMultisigRequest{Multisig: m}, &resp); err != nil {
+		return nil, fmt.Errorf("failed to sign approval remotely: %w", err)
+	}
+
+	if err := verifyApproval(s.publicKey, digest, resp.Signature); err != nil {
+		return nil, fmt.Errorf("signer returned an invalid signature: %w", err)
+	}
+
+	return resp.Signature, nil
+}
+
+func (s *RemoteSigner) do(method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, s.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(httpResp.Body, remoteSignerMaxBody))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		var errResp remoteSignResponse
+		if json.Unmarshal(payload, &errResp) == nil && errResp.Error != "" {
+			return fmt.Errorf("signer answered %s: %s", httpResp.Status, errResp.Error)
+		}
+		return fmt.Errorf("signer answered %s", httpResp.Status)
+	}
+
+	if err := json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// checkSignedTransaction makes sure a signer signed unsigned as given,
+// without changing any field.
+func checkSignedTransaction(unsigned, signed *transaction.Transaction) error {
+	if signed.Hash == "" || signed.Signature == "" {
+		return errors.New("hash or signature missing")
+	}
+
+	if signed.ChainID != unsigned.ChainID ||
+		signed.From != unsigned.From ||
+		signed.To != unsigned.To ||
+		signed.Method != unsigned.Method ||
+		signed.Version != unsigned.Version ||
+		signed.UUID7 != unsigned.UUID7 {
+		return errors.New("transaction fields were changed")
+	}
+
+	want, err := canonicalJSON(unsigned.Data)
+	if err != nil {
+		return fmt.Errorf("invalid transaction data: %w", err)
+	}
+	got, err := canonicalJSON(signed.Data)
+	if err != nil {
+		return fmt.Errorf("invalid signed transaction data: %w", err)
+	}
+	if !bytes.Equal(want, got) {
+		return errors.New("transaction data was changed")
+	}
+
+	return nil
+}
+
+// canonicalJSON re-encodes data so that equal values compare equal whatever
+// their spacing or key order. Empty data is treated as null.
+func canonicalJSON(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return []byte("null"), nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+// RemoteSignerHandlerOption configures RemoteSignerHandler.
+type RemoteSignerHandlerOption func(*remoteSignerHandler)
+
+type remoteSignerHandler struct {
+	multisigOnly bool
+}
+
+// WithMultisigOnly has the daemon sign only multi-signature transactions
+// whose approvals meet their threshold: POST /v1/sign is refused. Holding the
+// From account of a MultisigTransaction this way is what makes its
+// approvals binding.
+func WithMultisigOnly() RemoteSignerHandlerOption {
+	return func(h *remoteSignerHandler) {
+		h.multisigOnly = true
+	}
+}
+
+// RemoteSignerHandler serves the RemoteSigner protocol for signer. It is the
+// building block of a signing daemon: run it on a Unix socket or a loopback
+// HTTP listener in the process that owns the key.
+func RemoteSignerHandler(signer Signer, opts ...RemoteSignerHandlerOption) http.Handler {
+	var h remoteSignerHandler
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+remoteSignerPublicKeyPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeRemoteSignerJSON(w, http.StatusOK, remotePublicKeyResponse{PublicKey: signer.GetPublicKey()})
+	})
+
+	mux.HandleFunc("POST "+remoteSignerSignPath, func(w http.ResponseWriter, r *http.Request) {
+		if h.multisigOnly {
+			writeRemoteSignerJSON(w, http.StatusForbidden, remoteSignResponse{Error: "this signer only signs approved multisig transactions"})
+			return
+		}
+
+		var req remoteSignRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, remoteSignerMaxBody)).Decode(&req); err != nil {
+			writeRemoteSignerJSON(w, http.StatusBadRequest, remoteSignResponse{Error: "invalid request: " + err.Error()})
+			return
+		}
+
+		var data utils.JSONB
+		if len(req.Transaction.Data) > 0 {
+			if err := json.Unmarshal(req.Transaction.Data, &data); err != nil {
+				writeRemoteSignerJSON(w, http.StatusBadRequest, remoteSignResponse{Error: "invalid transaction data: " + err.Error()})
+				return
+			}
+		}
+
+		tx := req.Transaction
+		signed, err := signer.SignTransaction(tx.ChainID, tx.From, tx.To, tx.Method, data, tx.Version, tx.UUID7)
+		if err != nil {
+			writeRemoteSignerJSON(w, remoteSignerErrorStatus(err), remoteSignResponse{Error: err.Error()})
+			return
+		}
+
+		writeRemoteSignerJSON(w, http.StatusOK, remoteSignResponse{Transaction: *signed})
+	})
+
+	mux.HandleFunc("POST "+remoteSignerSignMultisigPath, func(w http.ResponseWriter, r *http.Request) {
+		m, err := readRemoteMultisigRequest(r)
+		if err != nil {
+			writeRemoteSignerJSON(w, http.StatusBadRequest, remoteSignResponse{Error: "invalid request: " + err.Error()})
+			return
+		}
+
+		// The threshold is checked here, on the side of the key, whatever
+		// the client checked.
+		if err := m.CheckThreshold(); err != nil {
+			writeRemoteSignerJSON(w, http.StatusForbidden, remoteSignResponse{Error: err.Error()})
+			return
+		}
+
+		data, err := m.TransactionData()
+		if err != nil {
+			writeRemoteSignerJSON(w, http.StatusBadRequest, remoteSignResponse{Error: err.Error()})
+			return
+		}
+
+		tx := m.Transaction
+		signed, err := signer.SignTransaction(tx.ChainID, tx.From, tx.To, tx.Method, data, tx.Version, tx.UUID7)
+		if err != nil {
+			writeRemoteSignerJSON(w, remoteSignerErrorStatus(err), remoteSignResponse{Error: err.Error()})
+			return
+		}
+
+		writeRemoteSignerJSON(w, http.StatusOK, remoteSignResponse{Transaction: *signed})
+	})
+
+	if approvalSigner, ok := signer.(ApprovalSigner); ok {
+		mux.HandleFunc("POST "+remoteSignerSignApprovalPath, func(w http.ResponseWriter, r *http.Request) {
+			m, err := readRemoteMultisigRequest(r)
+			if err != nil {
+				writeRemoteSignerJSON(w, http.StatusBadRequest, remoteSignApprovalResponse{Error: "invalid request: " + err.Error()})
+				return
+			}
+
+			signature, err := approvalSigner.SignApproval(m)
+			if err != nil {
+				writeRemoteSignerJSON(w, remoteSignerErrorStatus(err), remoteSignApprovalResponse{Error: err.Error()})
+				return
+			}
+
+			writeRemoteSignerJSON(w, http.StatusOK, remoteSignApprovalResponse{Signature: signature})
+		})
+	}
+
+	return mux
+}
+
+// readRemoteMultisigRequest decodes the multisig envelope of r with the
+// checks of ParseMultisigTransaction.
+func readRemoteMultisigRequest(r *http.Request) (*MultisigTransaction, error) {
+	var req struct {
+		Multisig json.RawMessage `json:"multisig"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, remoteSignerMaxBody)).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	return ParseMultisigTransaction(req.Multisig)
+}
+
+func remoteSignerErrorStatus(err error) int {
+	if errors.Is(err, ErrWalletLocked) {
+		return http.StatusLocked
+	}
+	return http.StatusUnprocessableEntity
+}
+
+func writeRemoteSignerJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,386 @@
+package wallet_manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gitlab.com/2finance/2finance-network/blockchain/encryption/keys"
+)
+
+const (
+	// WalletShareFormat identifies wallet shares in their format field.
+	WalletShareFormat = "2finance-wallet-share"
+
+	walletShareVersion = 1
+
+	// maxWalletShares is the most shares a key can be split into: share
+	// indexes are the non-zero elements of GF(256).
+	maxWalletShares = 255
+)
+
+// ShareCustodian is the holder of one share. A share is encrypted with the
+// custodian's Password through PasswordAEAD; an empty Password leaves it in
+// clear, for custodians that keep it offline.
+type ShareCustodian struct {
+	Name     string
+	Password string
+}
+
+// WalletShare is one Shamir share of a wallet private key, as handed to a
+// custodian. Any Threshold shares of the same split, told apart by SetID,
+// rebuild the key; fewer reveal nothing about it.
+type WalletShare struct {
+	Format    string `json:"format"`
+	Version   int    `json:"version"`
+	SetID     string `json:"set_id"`
+	Owner     string `json:"owner"`
+	Threshold int    `json:"threshold"`
+	Total     int    `json:"total"`
+	Index     int    `json:"index"`
+	Custodian string `json:"custodian,omitempty"`
+
+	// KDF is set when Share is encrypted with the custodian's password.
+	KDF      *KeysetKDFParams `json:"kdf,omitempty"`
+	Share    []byte           `json:"share"`
+	Checksum string           `json:"checksum"`
+}
+
+// RecoveryShare is a share handed back for recovery, with the custodian's
+// password when the share is encrypted.
+type RecoveryShare struct {
+	Data     []byte
+	Password string
+}
+
+// ParseWalletShare decodes a share and checks its format and checksum without
+// decrypting it.
+func ParseWalletShare(data []byte) (*WalletShare, error) {
+	var share WalletShare
+	if err := json.Unmarshal(data, &share); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wallet share: %w", err)
+	}
+
+	if share.Format != WalletShareFormat {
+		return nil, fmt.Errorf("not a wallet share: format %q", share.Format)
+	}
+
+	if share.Version != walletShareVersion {
+		return nil, fmt.Errorf("unsupported wallet share version: %d", share.Version)
+	}
+
+	if share.Threshold < 2 || share.Threshold > share.Total || share.Total > maxWalletShares ||
+		share.Index < 1 || share.Index > share.Total {
+		return nil, fmt.Errorf("invalid wallet share %d of %d, threshold %d", share.Index, share.Total, share.Threshold)
+	}
+
+	if share.KDF != nil {
+		if err := validateKDFParams(*share.KDF); err != nil {
+			return nil, fmt.Errorf("invalid wallet share: %w", err)
+		}
+	}
+
+	checksum, err := share.checksum()
+	if err != nil {
+		return nil, err
+	}
+	if share.Checksum != checksum {
+		return nil, errors.New("wallet share checksum mismatch: the share is damaged")
+	}
+
+	return &share, nil
+}
+
+// SplitShares splits the wallet private key into one share per custodian,
+// any threshold of which rebuild it with RecoverFromShares. password must
+// open the wallet: splitting is gated like ExportPrivateKey.
+func (w *WalletManager) SplitShares(password string, threshold int, custodians []ShareCustodian) ([][]byte, error) {
+	if threshold < 2 {
+		return nil, errors.New("share threshold must be at least 2")
+	}
+
+	if len(custodians) < threshold {
+		return nil, fmt.Errorf("share threshold %d is greater than the %d custodians", threshold, len(custodians))
+	}
+
+	if len(custodians) > maxWalletShares {
+		return nil, fmt.Errorf("at most %d custodians are supported", maxWalletShares)
+	}
+
+	privateKey, err := w.openPrivateKey(password)
+	if err != nil {
+		return nil, err
+	}
+	defer clearBytes(privateKey)
+
+	points, err := shamirSplit(privateKey, threshold, len(custodians))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, point := range points {
+			clearBytes(point)
+		}
+	}()
+
+	setID := uuid.NewString()
+	owner := w.GetPublicKey()
+
+	shares := make([][]byte, len(custodians))
+	for i, custodian := range custodians {
+		share := WalletShare{
+			Format:    WalletShareFormat,
+			Version:   walletShareVersion,
+			SetID:     setID,
+			Owner:     owner,
+			Threshold: threshold,
+			Total:     len(custodians),
+			Index:     i + 1,
+			Custodian: custodian.Name,
+		}
+
+		if custodian.Password == "" {
+			share.Share = cloneBytes(points[i])
+		} else if err := share.seal(points[i], custodian.Password); err != nil {
+			return nil, fmt.Errorf("failed to encrypt share for %s: %w", custodian.Name, err)
+		}
+
+		share.Checksum, err = share.checksum()
+		if err != nil {
+			return nil, err
+		}
+
+		shares[i], err = json.MarshalIndent(share, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal wallet share: %w", err)
+		}
+	}
+
+	return shares, nil
+}
+
+// RecoverFromShares rebuilds the private key from at least threshold shares
+// of one split and writes it to the wallet file, encrypted with password like
+// ImportWallet.
+func (w *WalletManager) RecoverFromShares(shares []RecoveryShare, password string) error {
+	if len(shares) == 0 {
+		return errors.New("wallet shares are required")
+	}
+
+	var first *WalletShare
+	points := make(map[int][]byte, len(shares))
+	defer func() {
+		for _, point := range points {
+			clearBytes(point)
+		}
+	}()
+
+	for _, recoveryShare := range shares {
+		share, err := ParseWalletShare(recoveryShare.Data)
+		if err != nil {
+			return err
+		}
+
+		if first == nil {
+			first = share
+		} else if share.SetID != first.SetID || share.Owner != first.Owner ||
+			share.Threshold != first.Threshold || share.Total != first.Total {
+			return fmt.Errorf("wallet share %d belongs to another split", share.Index)
+		}
+
+		if _, ok := points[share.Index]; ok {
+			return fmt.Errorf("wallet share %d given twice", share.Index)
+		}
+
+		point, err := share.open(recoveryShare.Password)
+		if err != nil {
+			return fmt.Errorf("failed to open wallet share %d: %w", share.Index, err)
+		}
+		points[share.Index] = point
+	}
+
+	if len(points) < first.Threshold {
+		return fmt.Errorf("%d of the %d wallet shares needed", len(points), first.Threshold)
+	}
+
+	privateKey, err := shamirCombine(points)
+	if err != nil {
+		return err
+	}
+	defer clearBytes(privateKey)
+
+	publicKey, err := keys.PublicKeyFromEd25519PrivateHex(string(privateKey))
+	if err != nil || keys.PublicKeyToHex(publicKey) != first.Owner {
+		return errors.New("wallet shares do not rebuild the expected key")
+	}
+
+	return w.ImportWallet(privateKey, password)
+}
+
+func (s *WalletShare) seal(point []byte, password string) error {
+	kdf, err := NewKeysetKDFParams()
+	if err != nil {
+		return err
+	}
+	s.KDF = &kdf
+
+	associatedData, err := s.associatedData()
+	if err != nil {
+		return err
+	}
+
+	passwordAEAD, err := NewPasswordAEAD(password, kdf)
+	if err != nil {
+		return err
+	}
+
+	s.Share, err = passwordAEAD.Encrypt(point, associatedData)
+
+	return err
+}
+
+func (s *WalletShare) open(password string) ([]byte, error) {
+	if s.KDF == nil {
+		return cloneBytes(s.Share), nil
+	}
+
+	if password == "" {
+		return nil, fmt.Errorf("share of %s is encrypted: %w", s.Custodian, ErrPasswordRequired)
+	}
+
+	associatedData, err := s.associatedData()
+	if err != nil {
+		return nil, err
+	}
+
+	passwordAEAD, err := NewPasswordAEAD(password, *s.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	return passwordAEAD.Decrypt(s.Share, associatedData)
+}
+
+// associatedData binds the header to the encrypted share.
+func (s *WalletShare) associatedData() ([]byte, error) {
+	header := *s
+	header.Share = nil
+	header.Checksum = ""
+
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal share header: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *WalletShare) checksum() (string, error) {
+	associatedData, err := s.associatedData()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.New()
+	sum.Write(associatedData)
+	sum.Write(s.Share)
+
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// shamirSplit splits secret byte by byte over GF(256) into n shares, any
+// threshold of which rebuild it. Share i is the value of the polynomials at
+// x = i+1.
+func shamirSplit(secret []byte, threshold, n int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret is required")
+	}
+
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret))
+	}
+
+	coefficients := make([]byte, threshold)
+	defer clearBytes(coefficients)
+
+	for b, value := range secret {
+		random, err := randomBytes(threshold - 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate share coefficients: %w", err)
+		}
+		coefficients[0] = value
+		copy(coefficients[1:], random)
+		clearBytes(random)
+
+		for i := range shares {
+			shares[i][b] = gfEvaluate(coefficients, byte(i+1))
+		}
+	}
+
+	return shares, nil
+}
+
+// shamirCombine interpolates the shares, keyed by their x coordinate, at
+// x = 0.
+func shamirCombine(points map[int][]byte) ([]byte, error) {
+	length := -1
+	for _, point := range points {
+		if length >= 0 && len(point) != length {
+			return nil, errors.New("wallet shares have different lengths")
+		}
+		length = len(point)
+	}
+
+	secret := make([]byte, length)
+	for xj, yj := range points {
+		// Lagrange basis at 0: the product of xm / (xm - xj), and
+		// subtraction is XOR in GF(256).
+		basis := byte(1)
+		for xm := range points {
+			if xm == xj {
+				continue
+			}
+			basis = gfMul(basis, gfMul(byte(xm), gfInverse(byte(xm)^byte(xj))))
+		}
+
+		for b := range secret {
+			secret[b] ^= gfMul(yj[b], basis)
+		}
+	}
+
+	return secret, nil
+}
+
+func gfEvaluate(coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ coefficients[i]
+	}
+	return y
+}
+
+// gfMul multiplies in GF(256) modulo x^8 + x^4 + x^3 + x + 1 without
+// branching on the operands.
+func gfMul(a, b byte) byte {
+	var product byte
+	for i := 0; i < 8; i++ {
+		product ^= a & -(b & 1)
+		a = a<<1 ^ 0x1b&-(a>>7)
+		b >>= 1
+	}
+	return product
+}
+
+// gfInverse returns a^254, the inverse of a non-zero a.
+func gfInverse(a byte) byte {
+	result := byte(1)
+	for i := 0; i < 7; i++ {
+		a = gfMul(a, a)
+		result = gfMul(result, a)
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package wallet_manager
+
+import (
+	"fmt"
+
+	"gitlab.com/2finance/2finance-network/blockchain/transaction"
+	"gitlab.com/2finance/2finance-network/blockchain/utils"
+)
+
+// Signer signs transactions for one account. WalletManager is the local
+// implementation, holding the key in process memory while unlocked;
+// RemoteSigner and PKCS11Signer keep the key outside the process.
+type Signer interface {
+	// GetPublicKey returns the hex public key transactions are sent from.
+	GetPublicKey() string
+
+	// IsUnlocked reports whether SignTransaction can sign right now.
+	IsUnlocked() bool
+
+	SignTransaction(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error)
+}
+
+// PasswordSigner is a Signer that can require a password to sign some
+// methods. WalletManager is one.
+type PasswordSigner interface {
+	Signer
+
+	// RequiresPassword reports whether SignTransaction refuses methodName
+	// with ErrPasswordRequired.
+	RequiresPassword(methodName string) bool
+
+	// SignTransactionWithPassword signs after checking password.
+	SignTransactionWithPassword(password string, chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error)
+}
+
+// NewUnsignedTransaction builds the transaction every Signer signs, with
+// neither hash nor signature.
+func NewUnsignedTransaction(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error) {
+	dataRawMessage, err := utils.MapToRawMessage(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert data to RawMessage: %w", err)
+	}
+
+	return transaction.NewTransaction(chainId, from, to, method, dataRawMessage, version, uuid7).Get(), nil
+}
+
+// checkSigner rejects transactions from an account other than the signer's.
+func checkSigner(signer Signer, from string) error {
+	if publicKey := signer.GetPublicKey(); from != publicKey {
+		return fmt.Errorf("signer %s cannot sign for %s", publicKey, from)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package wallet_manager
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// UnlockPolicy decides how long Unlock keeps the private key in memory.
+type UnlockPolicy struct {
+	// TTL is how long the wallet stays unlocked. Zero keeps it unlocked
+	// until Lock is called.
+	TTL time.Duration
+
+	// Sliding restarts the TTL after every signature, so the wallet locks
+	// once it has been idle for TTL.
+	Sliding bool
+
+	// MaxSignatures locks the wallet once it has signed that many
+	// transactions since Unlock. Zero means no limit.
+	MaxSignatures int
+}
+
+// DefaultUnlockPolicy keeps the wallet unlocked for two minutes.
+func DefaultUnlockPolicy() UnlockPolicy {
+	return FixedUnlock(unlockDuration)
+}
+
+// FixedUnlock locks the wallet ttl after Unlock, whatever it signs.
+func FixedUnlock(ttl time.Duration) UnlockPolicy {
+	return UnlockPolicy{TTL: ttl}
+}
+
+// SlidingUnlock locks the wallet once it has not signed for ttl.
+func SlidingUnlock(ttl time.Duration) UnlockPolicy {
+	return UnlockPolicy{TTL: ttl, Sliding: true}
+}
+
+// SignatureBudgetUnlock locks the wallet after maxSignatures signatures.
+// Set TTL on the result to also bound the time.
+func SignatureBudgetUnlock(maxSignatures int) UnlockPolicy {
+	return UnlockPolicy{MaxSignatures: maxSignatures}
+}
+
+// UnlockUntilLock keeps the wallet unlocked until Lock is called.
+func UnlockUntilLock() UnlockPolicy {
+	return UnlockPolicy{}
+}
+
+func (p UnlockPolicy) validate() error {
+	if p.TTL < 0 {
+		return errors.New("unlock TTL must not be negative")
+	}
+
+	if p.Sliding && p.TTL == 0 {
+		return errors.New("sliding unlock requires a TTL")
+	}
+
+	if p.MaxSignatures < 0 {
+		return errors.New("max signatures must not be negative")
+	}
+
+	return nil
+}
+
+// LockReason tells OnLock hooks why the wallet was locked.
+type LockReason string
+
+const (
+	// LockReasonExplicit is a call to Lock or ForceLock, or to a method that
+	// rewrites the wallet file such as ImportWallet or RotatePassword.
+	LockReasonExplicit LockReason = "explicit"
+
+	// LockReasonExpired is the unlock TTL running out.
+	LockReasonExpired LockReason = "expired"
+
+	// LockReasonSignatureBudget is the MaxSignatures budget being spent.
+	LockReasonSignatureBudget LockReason = "signature_budget"
+)
+
+// SetUnlockPolicy sets the policy applied by the next Unlock. A wallet that
+// is already unlocked keeps the policy it was unlocked with.
+func (w *WalletManager) SetUnlockPolicy(policy UnlockPolicy) error {
+	if err := policy.validate(); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.unlockPolicy = policy
+
+	return nil
+}
+
+// OnLock registers hook to run whenever the unlocked wallet is locked. Hooks
+// run after the wallet's mutex is released, so they may call back into the
+// manager; one locking through the TTL runs on its own goroutine.
+func (w *WalletManager) OnLock(hook func(reason LockReason)) {
+	if hook == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.onLock = append(w.onLock, hook)
+}
+
+// OnUnlock registers hook to run after every successful Unlock with the time
+// the wallet will lock, zero when the policy has no TTL.
+func (w *WalletManager) OnUnlock(hook func(until time.Time)) {
+	if hook == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.onUnlock = append(w.onUnlock, hook)
+}
+
+// UnlockedUntil returns when the wallet will lock by itself. It is zero when
+// the wallet is locked and when it stays unlocked until Lock; IsUnlocked
+// tells the two apart. With a sliding policy every signature moves it.
+func (w *WalletManager) UnlockedUntil() time.Time {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if !w.isUnlockedLocked() {
+		return time.Time{}
+	}
+
+	return w.unlockedUntil
+}
+
+// startSessionLocked applies the unlock policy to the key just loaded and
+// returns the OnUnlock hooks to run.
+func (w *WalletManager) startSessionLocked() func() {
+	w.sessionPolicy = w.unlockPolicy
+	w.signatures = 0
+
+	if ttl := w.sessionPolicy.TTL; ttl > 0 {
+		w.unlockedUntil = time.Now().Add(ttl)
+		w.lockTimer = time.AfterFunc(ttl, w.expire)
+	}
+
+	until := w.unlockedUntil
+	hooks := slices.Clone(w.onUnlock)
+
+	return func() {
+		for _, hook := range hooks {
+			hook(until)
+		}
+	}
+}
+
+// recordSignatureLocked counts a signature against the session, sliding the
+// TTL or spending the budget, and returns the OnLock hooks to run if that
+// locked the wallet.
+func (w *WalletManager) recordSignatureLocked() func() {
+	w.signatures++
+
+	policy := w.sessionPolicy
+	if policy.MaxSignatures > 0 && w.signatures >= policy.MaxSignatures {
+		return w.lockLocked(LockReasonSignatureBudget)
+	}
+
+	if policy.Sliding && w.lockTimer != nil {
+		w.unlockedUntil = time.Now().Add(policy.TTL)
+		w.lockTimer.Reset(policy.TTL)
+	}
+
+	return noNotice
+}
+
+// lockLocked clears the key and returns the OnLock hooks to run, none when the
+// wallet was already locked.
+func (w *WalletManager) lockLocked(reason LockReason) func() {
+	wasUnlocked := len(w.privateKey) > 0
+	w.lockMemoryLocked()
+
+	if !wasUnlocked || len(w.onLock) == 0 {
+		return noNotice
+	}
+
+	hooks := slices.Clone(w.onLock)
+
+	return func() {
+		for _, hook := range hooks {
+			hook(reason)
+		}
+	}
+}
+
+// expire is run by the lock timer. The deadline is checked again because a
+// signature may have slid it, or a new Unlock replaced the session, while
+// the timer was firing.
+func (w *WalletManager) expire() {
+	notify := noNotice
+	defer func() { notify() }()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.privateKey) == 0 || w.unlockedUntil.IsZero() || time.Now().Before(w.unlockedUntil) {
+		return
+	}
+
+	notify = w.lockLocked(LockReasonExpired)
+}
+
+func noNotice() {}
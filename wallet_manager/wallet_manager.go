@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -13,10 +14,51 @@ import (
 )
 
 const (
-	walletVersion  = 1
+	walletVersion  = 2
 	unlockDuration = 2 * time.Minute
 )
 
+var (
+	// ErrWalletLocked is returned when the private key is needed and the
+	// wallet is locked.
+	ErrWalletLocked = errors.New("wallet is locked")
+
+	// ErrPasswordRequired is returned when signing a method that requires
+	// the wallet password without giving it.
+	ErrPasswordRequired = errors.New("password is required")
+)
+
+// WalletLockedError is returned by SignTransaction when the wallet is locked,
+// including after the unlock policy has locked it. It matches
+// ErrWalletLocked.
+type WalletLockedError struct {
+	Owner  string
+	Method string
+}
+
+func (e *WalletLockedError) Error() string {
+	return fmt.Sprintf("wallet %s is locked: cannot sign %s", e.Owner, e.Method)
+}
+
+func (e *WalletLockedError) Is(target error) bool {
+	return target == ErrWalletLocked
+}
+
+// PasswordRequiredError is returned by SignTransaction for a method that
+// requires the wallet password; sign it with SignTransactionWithPassword. It
+// matches ErrPasswordRequired.
+type PasswordRequiredError struct {
+	Method string
+}
+
+func (e *PasswordRequiredError) Error() string {
+	return fmt.Sprintf("password is required to sign %s", e.Method)
+}
+
+func (e *PasswordRequiredError) Is(target error) bool {
+	return target == ErrPasswordRequired
+}
+
 type WalletFile struct {
 	Version             int               `json:"version"`
 	Owner               string            `json:"owner"`
@@ -24,6 +66,10 @@ type WalletFile struct {
 	WrappedKeyset       WrappedTinkKeyset `json:"wrapped_keyset"`
 	CreatedAt           time.Time         `json:"created_at"`
 	UpdatedAt           time.Time         `json:"updated_at"`
+
+	// Added in version 2.
+	KeyType    string            `json:"key_type,omitempty"`
+	Migrations []WalletMigration `json:"migrations,omitempty"`
 }
 
 type WalletManager struct {
@@ -37,37 +83,72 @@ type WalletManager struct {
 	unlockedUntil time.Time
 	lockTimer     *time.Timer
 
+	unlockPolicy  UnlockPolicy
+	sessionPolicy UnlockPolicy
+	signatures    int
+
+	onLock   []func(LockReason)
+	onUnlock []func(time.Time)
+
 	passwordRequiredMethods map[string]bool
 }
 
 type IWalletManager interface {
+	// PasswordSigner covers GetPublicKey, IsUnlocked, RequiresPassword,
+	// SignTransaction and SignTransactionWithPassword; ApprovalSigner adds
+	// SignApproval.
+	PasswordSigner
+	ApprovalSigner
+
 	ImportWallet(privateKey []byte, password string) error
+	ImportMnemonic(mnemonic, passphrase string, accountIndex uint32, password string) error
 	Lock() error
 	Unlock(password string) error
 	ForceLock() error
-	IsUnlocked() bool
-	RequiresPassword(methodName string) bool
+	SetPasswordRequired(methodName string, required bool)
+	SetUnlockPolicy(policy UnlockPolicy) error
+	UnlockedUntil() time.Time
+	OnLock(hook func(reason LockReason))
+	OnUnlock(hook func(until time.Time))
 	RotatePassword(currentPassword string, newPassword string) error
+	Migrate(password string) (bool, error)
+	ExportBackup(password, backupPassword string) ([]byte, error)
+	ImportBackup(backup []byte, backupPassword, password string) error
+	SplitShares(password string, threshold int, custodians []ShareCustodian) ([][]byte, error)
+	RecoverFromShares(shares []RecoveryShare, password string) error
 	GetPrivateKey(methodName string, password string) ([]byte, error)
 
-	GetPublicKey() string
 	GenerateEd25519KeyPairHex() (string, string, error)
-	SignTransaction(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error)
 }
 
 func NewWalletManager(filePath string) IWalletManager {
+	return newWalletManager(filePath)
+}
+
+func newWalletManager(filePath string) *WalletManager {
 	return &WalletManager{
-		filePath: filePath,
-		passwordRequiredMethods: map[string]bool{
-			"ExportPrivateKey": true,
-			"ChangePassword":   true,
-			"DeleteWallet":     true,
-			"Withdraw":         true,
-		},
+		filePath:                filePath,
+		unlockPolicy:            DefaultUnlockPolicy(),
+		passwordRequiredMethods: defaultPasswordRequiredMethods(),
+	}
+}
+
+// defaultPasswordRequiredMethods lists the wallet operations that need the
+// password even while the wallet is unlocked. Contract methods are signed
+// with the session by default; callers opt them in with SetPasswordRequired,
+// e.g. for dropV1.METHOD_WITHDRAW_DROP.
+func defaultPasswordRequiredMethods() map[string]bool {
+	return map[string]bool{
+		"ExportPrivateKey": true,
+		"ChangePassword":   true,
+		"DeleteWallet":     true,
 	}
 }
 
 func (w *WalletManager) ImportWallet(privateKey []byte, password string) error {
+	notify := noNotice
+	defer func() { notify() }()
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
@@ -79,9 +160,11 @@ func (w *WalletManager) ImportWallet(privateKey []byte, password string) error {
 		return fmt.Errorf("private key is required")
 	}
 
-	if w.filePath == "" {
-		return fmt.Errorf("wallet file path is required")
+	unlockFile, err := w.lockWalletFileLocked()
+	if err != nil {
+		return err
 	}
+	defer unlockFile()
 
 	privateKeyHex := string(privateKey)
 
@@ -123,6 +206,7 @@ func (w *WalletManager) ImportWallet(privateKey []byte, password string) error {
 		WrappedKeyset:       wrappedKeyset,
 		CreatedAt:           now,
 		UpdatedAt:           now,
+		KeyType:             walletKeyTypeEd25519,
 	}
 
 	walletPayload, err := json.Marshal(walletFile)
@@ -143,16 +227,19 @@ func (w *WalletManager) ImportWallet(privateKey []byte, password string) error {
 	}
 
 	clearBytes(privateKey)
-	w.lockMemoryLocked()
+	notify = w.lockLocked(LockReasonExplicit)
 
 	return nil
 }
 
 func (w *WalletManager) Lock() error {
+	notify := noNotice
+	defer func() { notify() }()
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	w.lockMemoryLocked()
+	notify = w.lockLocked(LockReasonExplicit)
 
 	return nil
 }
@@ -161,46 +248,66 @@ func (w *WalletManager) ForceLock() error {
 	return w.Lock()
 }
 
+// Unlock decrypts the private key with password and keeps it in memory as
+// the unlock policy allows. Unlocking an unlocked wallet starts a new
+// session.
 func (w *WalletManager) Unlock(password string) error {
+	notify := noNotice
+	defer func() { notify() }()
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if password == "" {
-		return errors.New("password is required")
+	privateKey, err := w.openPrivateKeyLocked(password)
+	if err != nil {
+		return err
 	}
 
-	if w.filePath == "" {
-		return fmt.Errorf("wallet file path is required")
-	}
+	w.lockMemoryLocked()
 
-	encryptionFile := NewEncryptionFile(w.filePath)
+	w.privateKey = privateKey
 
-	localEncryptedWalletFile, err := encryptionFile.Read()
-	if err != nil {
-		return fmt.Errorf("failed to read wallet file: %w", err)
-	}
+	notify = w.startSessionLocked()
 
-	walletPayload, err := encryptionFile.Decrypt(*localEncryptedWalletFile, password)
-	if err != nil {
-		return fmt.Errorf("failed to decrypt wallet file: %w", err)
+	return nil
+}
+
+// openPrivateKey decrypts the private key with password for a one-off use
+// such as an export. Unlike GetPrivateKey it neither starts nor changes the
+// unlock session, and it takes the password even while the wallet is
+// unlocked.
+func (w *WalletManager) openPrivateKey(password string) ([]byte, error) {
+	if password == "" {
+		return nil, ErrPasswordRequired
 	}
-	defer clearBytes(walletPayload)
 
-	var walletFile WalletFile
-	if err := json.Unmarshal(walletPayload, &walletFile); err != nil {
-		return fmt.Errorf("failed to unmarshal wallet file: %w", err)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.openPrivateKeyLocked(password)
+}
+
+// openPrivateKeyLocked decrypts the private key of the wallet file with
+// password, migrating the file when it is outdated. The caller owns the
+// returned key.
+func (w *WalletManager) openPrivateKeyLocked(password string) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("password is required")
 	}
 
-	if walletFile.Version != walletVersion {
-		return fmt.Errorf("unsupported wallet version: %d", walletFile.Version)
+	unlockFile, err := w.lockWalletFileLocked()
+	if err != nil {
+		return nil, err
 	}
+	defer unlockFile()
 
-	if walletFile.Owner == "" {
-		return fmt.Errorf("wallet owner is required")
+	localEncryptedWalletFile, walletFile, err := w.readWalletFileLocked(password)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(walletFile.EncryptedPrivateKey) == 0 {
-		return fmt.Errorf("encrypted private key is required")
+		return nil, fmt.Errorf("encrypted private key is required")
 	}
 
 	// Se o manager ainda não tem owner, carrega do arquivo.
@@ -211,36 +318,32 @@ func (w *WalletManager) Unlock(password string) error {
 
 	// Se o manager já tinha owner e o arquivo é de outro owner, bloqueia.
 	if walletFile.Owner != w.owner {
-		return fmt.Errorf("wallet owner mismatch")
+		return nil, fmt.Errorf("wallet owner mismatch")
 	}
 
 	kh, err := UnwrapTinkKeyset(walletFile.WrappedKeyset, password)
 	if err != nil {
-		return fmt.Errorf("failed to unwrap keyset: %w", err)
+		return nil, fmt.Errorf("failed to unwrap keyset: %w", err)
 	}
 
 	encryptionKey := NewEncryption(walletFile.Owner)
 
 	if err := encryptionKey.LoadAEAD(kh); err != nil {
-		return fmt.Errorf("failed to load wallet AEAD: %w", err)
+		return nil, fmt.Errorf("failed to load wallet AEAD: %w", err)
 	}
 
 	privateKey, err := encryptionKey.DecryptPrivateKey(walletFile.EncryptedPrivateKey)
 	if err != nil {
-		return fmt.Errorf("failed to decrypt private key: %w", err)
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
 	}
 
-	w.lockMemoryLocked()
-
-	w.privateKey = privateKey
-	w.owner = walletFile.Owner
-	w.unlockedUntil = time.Now().Add(unlockDuration)
-
-	w.lockTimer = time.AfterFunc(unlockDuration, func() {
-		_ = w.Lock()
-	})
+	// Older files are upgraded now that the password is at hand.
+	if _, err := w.migrateLocked(password, localEncryptedWalletFile, &walletFile); err != nil {
+		clearBytes(privateKey)
+		return nil, fmt.Errorf("failed to migrate wallet file: %w", err)
+	}
 
-	return nil
+	return privateKey, nil
 }
 
 func (w *WalletManager) IsUnlocked() bool {
@@ -251,7 +354,11 @@ func (w *WalletManager) IsUnlocked() bool {
 }
 
 func (w *WalletManager) isUnlockedLocked() bool {
-	return len(w.privateKey) > 0 && time.Now().Before(w.unlockedUntil)
+	if len(w.privateKey) == 0 {
+		return false
+	}
+
+	return w.unlockedUntil.IsZero() || time.Now().Before(w.unlockedUntil)
 }
 
 func (w *WalletManager) RequiresPassword(methodName string) bool {
@@ -261,10 +368,26 @@ func (w *WalletManager) RequiresPassword(methodName string) bool {
 	return w.passwordRequiredMethods[methodName]
 }
 
+// SetPasswordRequired sets whether methodName needs the wallet password on
+// every use, even while the wallet is unlocked. methodName is either a
+// wallet operation such as "ExportPrivateKey" or a contract method signed by
+// SignTransaction, such as the ones behind WithdrawDrop, WithdrawRaffle or
+// RevokeMintAuthority.
+func (w *WalletManager) SetPasswordRequired(methodName string, required bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if required {
+		w.passwordRequiredMethods[methodName] = true
+		return
+	}
+	delete(w.passwordRequiredMethods, methodName)
+}
+
 func (w *WalletManager) GetPrivateKey(methodName string, password string) ([]byte, error) {
 	if w.RequiresPassword(methodName) {
 		if password == "" {
-			return nil, errors.New("password is required")
+			return nil, ErrPasswordRequired
 		}
 
 		if err := w.Unlock(password); err != nil {
@@ -274,7 +397,7 @@ func (w *WalletManager) GetPrivateKey(methodName string, password string) ([]byt
 
 	if !w.IsUnlocked() {
 		if password == "" {
-			return nil, errors.New("wallet is locked")
+			return nil, ErrWalletLocked
 		}
 
 		if err := w.Unlock(password); err != nil {
@@ -288,6 +411,16 @@ func (w *WalletManager) GetPrivateKey(methodName string, password string) ([]byt
 	return cloneBytes(w.privateKey), nil
 }
 
+// lockWalletFileLocked takes the cross-process lock of the wallet file, held
+// while the file is read and rewritten.
+func (w *WalletManager) lockWalletFileLocked() (func(), error) {
+	if w.filePath == "" {
+		return nil, fmt.Errorf("wallet file path is required")
+	}
+
+	return LockFile(w.filePath)
+}
+
 func (w *WalletManager) lockMemoryLocked() {
 	if w.lockTimer != nil {
 		w.lockTimer.Stop()
@@ -303,6 +436,9 @@ func (w *WalletManager) lockMemoryLocked() {
 }
 
 func (w *WalletManager) RotatePassword(currentPassword string, newPassword string) error {
+	notify := noNotice
+	defer func() { notify() }()
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
@@ -322,9 +458,11 @@ func (w *WalletManager) RotatePassword(currentPassword string, newPassword strin
 		return fmt.Errorf("owner is required")
 	}
 
-	if w.filePath == "" {
-		return fmt.Errorf("wallet file path is required")
+	unlockFile, err := w.lockWalletFileLocked()
+	if err != nil {
+		return err
 	}
+	defer unlockFile()
 
 	encryptionFile := NewEncryptionFile(w.filePath)
 
@@ -344,8 +482,8 @@ func (w *WalletManager) RotatePassword(currentPassword string, newPassword strin
 		return fmt.Errorf("failed to unmarshal wallet file: %w", err)
 	}
 
-	if walletFile.Version != walletVersion {
-		return fmt.Errorf("unsupported wallet version: %d", walletFile.Version)
+	if err := checkWalletVersion(walletFile.Version); err != nil {
+		return err
 	}
 
 	if walletFile.Owner != w.owner {
@@ -365,6 +503,23 @@ func (w *WalletManager) RotatePassword(currentPassword string, newPassword strin
 	walletFile.WrappedKeyset = newWrappedKeyset
 	walletFile.UpdatedAt = time.Now()
 
+	// Backups that still open with the current password would let anyone who
+	// learns the retired password read the key, so they go once the rotated
+	// file is on disk. Backups under other passwords are kept.
+	var retiredBackups []string
+	for i := range walletFile.Migrations {
+		if walletFile.Migrations[i].BackupFile == "" {
+			continue
+		}
+
+		backupPath, err := migrationBackupPath(w.filePath, walletFile.Migrations[i].BackupFile)
+		if err != nil || !walletBackupOpensWith(backupPath, currentPassword) {
+			continue
+		}
+		retiredBackups = append(retiredBackups, backupPath)
+		walletFile.Migrations[i].BackupFile = ""
+	}
+
 	updatedWalletPayload, err := json.Marshal(walletFile)
 	if err != nil {
 		return fmt.Errorf("failed to marshal updated wallet file: %w", err)
@@ -380,7 +535,19 @@ func (w *WalletManager) RotatePassword(currentPassword string, newPassword strin
 		return fmt.Errorf("failed to write rotated wallet file: %w", err)
 	}
 
-	w.lockMemoryLocked()
+	// The rotation has succeeded; removing the retired backups is best
+	// effort. The rolling ones include the file just replaced.
+	rollingBackups, _ := encryptionFile.Backups()
+	for _, backupPath := range rollingBackups {
+		if walletBackupOpensWith(backupPath, currentPassword) {
+			retiredBackups = append(retiredBackups, backupPath)
+		}
+	}
+	for _, backupPath := range retiredBackups {
+		_ = os.Remove(backupPath)
+	}
+
+	notify = w.lockLocked(LockReasonExplicit)
 
 	return nil
 }
@@ -401,21 +568,70 @@ func (w *WalletManager) GetPublicKey() string {
 	return w.owner
 }
 
+// SignTransaction signs with the unlocked key. It fails with a
+// *WalletLockedError when the wallet is locked and with a
+// *PasswordRequiredError when method requires the password. Each signature
+// counts against the unlock policy.
 func (w *WalletManager) SignTransaction(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error) {
+	notify := noNotice
+	defer func() { notify() }()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.passwordRequiredMethods[method] {
+		return nil, &PasswordRequiredError{Method: method}
+	}
 
-	dataRawMessage, err := utils.MapToRawMessage(data)
+	signedTx, err := w.signTransactionLocked(chainId, from, to, method, data, version, uuid7)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert data to RawMessage: %w", err)
+		return nil, err
 	}
+	notify = w.recordSignatureLocked()
 
-	// 1. create new tx
-	newTx := transaction.NewTransaction(chainId, from, to, method, dataRawMessage, version, uuid7)
+	return signedTx, nil
+}
 
-	// 2. get serialized form (here it's just the object)
-	tx := newTx.Get()
+// SignTransactionWithPassword signs with the key password decrypts from the
+// wallet file, whether or not method requires the password. It works on a
+// locked wallet and leaves the unlock session, if any, as it was: the wallet
+// is neither unlocked nor charged for the signature.
+func (w *WalletManager) SignTransactionWithPassword(password string, chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error) {
+	if password == "" {
+		return nil, &PasswordRequiredError{Method: method}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	privateKey, err := w.openPrivateKeyLocked(password)
+	if err != nil {
+		return nil, err
+	}
+	defer clearBytes(privateKey)
+
+	return signTransactionWithKey(privateKey, chainId, from, to, method, data, version, uuid7)
+}
+
+func (w *WalletManager) signTransactionLocked(chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error) {
+	if !w.isUnlockedLocked() {
+		return nil, &WalletLockedError{Owner: w.owner, Method: method}
+	}
+
+	return signTransactionWithKey(w.privateKey, chainId, from, to, method, data, version, uuid7)
+}
+
+// signTransactionWithKey signs a new transaction with the hex private key
+// privateKey.
+func signTransactionWithKey(privateKey []byte, chainId uint8, from, to, method string, data utils.JSONB, version uint8, uuid7 string) (*transaction.Transaction, error) {
+	// 1. create new tx
+	tx, err := NewUnsignedTransaction(chainId, from, to, method, data, version, uuid7)
+	if err != nil {
+		return nil, err
+	}
 
-	// 3. sign
-	signedTx, err := transaction.SignTransactionHexKey(string(w.privateKey), tx)
+	// 2. sign
+	signedTx, err := transaction.SignTransactionHexKey(string(privateKey), tx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}